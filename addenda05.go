@@ -41,6 +41,8 @@ type Addenda05 struct {
 	// the same as the last seven digits of the trace number of the related
 	// Entry Detail Record or Corporate Entry Detail Record.
 	EntryDetailSequenceNumber int `json:"entryDetailSequenceNumber"`
+	// validateOpts defines optional overrides for record validation
+	validateOpts *ValidateOpts
 	// validator is composed for data validation
 	validator
 	// converters is composed for ACH to GoLang Converters
@@ -113,9 +115,9 @@ func (addenda05 *Addenda05) String() string {
 
 	buf.WriteString(entryAddendaPos)
 	buf.WriteString(addenda05.TypeCode)
-	buf.WriteString(addenda05.PaymentRelatedInformationField())
-	buf.WriteString(addenda05.SequenceNumberField())
-	buf.WriteString(addenda05.EntryDetailSequenceNumberField())
+	addenda05.writeAlphaField(buf, addenda05.PaymentRelatedInformation, 80)
+	addenda05.writeNumericField(buf, addenda05.SequenceNumber, 4)
+	addenda05.writeNumericField(buf, addenda05.EntryDetailSequenceNumber, 7)
 
 	return buf.String()
 }
@@ -137,10 +139,24 @@ func (addenda05 *Addenda05) Validate() error {
 	if err := addenda05.isAlphanumeric(addenda05.PaymentRelatedInformation); err != nil {
 		return fieldError("PaymentRelatedInformation", err, addenda05.PaymentRelatedInformation)
 	}
+	if addenda05.validateOpts == nil || !addenda05.validateOpts.TruncatePaymentRelatedInformation {
+		if utf8.RuneCountInString(addenda05.PaymentRelatedInformation) > 80 {
+			return fieldError("PaymentRelatedInformation", NewErrValidFieldLength(80), addenda05.PaymentRelatedInformation)
+		}
+	}
 
 	return nil
 }
 
+// SetValidation stores ValidateOpts on the Addenda05 which are to be used to override
+// the default NACHA validation rules.
+func (addenda05 *Addenda05) SetValidation(opts *ValidateOpts) {
+	if addenda05 == nil {
+		return
+	}
+	addenda05.validateOpts = opts
+}
+
 // fieldInclusion validate mandatory fields are not default values. If fields are
 // invalid the ACH transfer will be returned.
 func (addenda05 *Addenda05) fieldInclusion() error {
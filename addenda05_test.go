@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
 )
 
 func mockAddenda05() *Addenda05 {
@@ -195,3 +196,53 @@ func TestAddenda05RuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString not equal to 94")
 	}
 }
+
+// TestAddenda05PaymentRelatedInformationLength validates PaymentRelatedInformation is at most 80 characters
+func TestAddenda05PaymentRelatedInformationLength(t *testing.T) {
+	addenda05 := mockAddenda05()
+	addenda05.PaymentRelatedInformation = strings.Repeat("A", 100)
+
+	err := addenda05.Validate()
+	if !base.Match(err, NewErrValidFieldLength(80)) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// Formatting still fits the record by truncating to 80 characters
+	if n := len(addenda05.PaymentRelatedInformationField()); n != 80 {
+		t.Errorf("unexpected PaymentRelatedInformationField length: %d", n)
+	}
+	if n := len(addenda05.String()); n != RecordLength {
+		t.Errorf("unexpected record length: %d", n)
+	}
+
+	addenda05.PaymentRelatedInformation = strings.Repeat("A", 80)
+	if err := addenda05.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddenda05__TruncatePaymentRelatedInformation(t *testing.T) {
+	addenda05 := mockAddenda05()
+	addenda05.PaymentRelatedInformation = strings.Repeat("A", 80) + strings.Repeat("B", 20)
+	addenda05.SetValidation(&ValidateOpts{
+		TruncatePaymentRelatedInformation: true,
+	})
+	require.NoError(t, addenda05.Validate())
+
+	line := addenda05.String()
+	require.Len(t, line, RecordLength)
+	require.Equal(t, strings.Repeat("A", 80), line[3:83])
+
+	// other checks still apply
+	addenda05.PaymentRelatedInformation = "®"
+	require.Error(t, addenda05.Validate())
+
+	// options set on the entry are passed to its addenda
+	ed := mockPPDEntryDetail()
+	other := mockAddenda05()
+	other.PaymentRelatedInformation = strings.Repeat("C", 100)
+	ed.AddAddenda05(other)
+	require.Error(t, other.Validate())
+	ed.SetValidation(&ValidateOpts{TruncatePaymentRelatedInformation: true})
+	require.NoError(t, other.Validate())
+}
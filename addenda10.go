@@ -20,6 +20,8 @@ package ach
 import (
 	"strings"
 	"unicode/utf8"
+
+	"github.com/moov-io/iso4217"
 )
 
 // Addenda10 is an addenda which provides business transaction information for Addenda Type
@@ -205,6 +207,32 @@ func (addenda10 *Addenda10) ForeignPaymentAmountField() string {
 	return addenda10.numericField(addenda10.ForeignPaymentAmount, 18)
 }
 
+// SetForeignPaymentAmount sets ForeignPaymentAmount from amount in the minor units of currencyCode,
+// such as yen for JPY or fils for BHD. ForeignPaymentAmount always carries two implied decimal places,
+// so amounts in currencies with fewer decimal places are scaled up and those with more are rounded half up.
+func (addenda10 *Addenda10) SetForeignPaymentAmount(amount int, currencyCode string) error {
+	cc, exists := iso4217.Lookup(currencyCode)
+	if !exists {
+		return fieldError("ISODestinationCurrencyCode", ErrValidISO4217, currencyCode)
+	}
+	if amount < 0 {
+		return fieldError("ForeignPaymentAmount", ErrNegativeAmount, amount)
+	}
+	places := int(cc.DecimalPlaces)
+	for ; places < 2; places++ {
+		amount *= 10
+	}
+	if places > 2 {
+		divisor := 1
+		for ; places > 2; places-- {
+			divisor *= 10
+		}
+		amount = (amount + divisor/2) / divisor
+	}
+	addenda10.ForeignPaymentAmount = amount
+	return nil
+}
+
 // ForeignTraceNumberField gets the Foreign TraceNumber left padded
 func (addenda10 *Addenda10) ForeignTraceNumberField() string {
 	return addenda10.alphaField(addenda10.ForeignTraceNumber, 22)
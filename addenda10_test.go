@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
 )
 
 // mockAddenda10 creates a mock Addenda10 record
@@ -352,3 +353,27 @@ func TestAddenda10RuneCountInString(t *testing.T) {
 		t.Error("Parsed with an invalid RuneCountInString not equal to 94")
 	}
 }
+
+func TestAddenda10SetForeignPaymentAmount(t *testing.T) {
+	addenda10 := mockAddenda10()
+
+	require.NoError(t, addenda10.SetForeignPaymentAmount(123456, "EUR"))
+	require.Equal(t, 123456, addenda10.ForeignPaymentAmount)
+
+	// JPY has no minor unit
+	require.NoError(t, addenda10.SetForeignPaymentAmount(1500, "jpy"))
+	require.Equal(t, 150000, addenda10.ForeignPaymentAmount)
+
+	// BHD has three decimal places
+	require.NoError(t, addenda10.SetForeignPaymentAmount(1235, "BHD"))
+	require.Equal(t, 124, addenda10.ForeignPaymentAmount)
+	require.NoError(t, addenda10.SetForeignPaymentAmount(1234, "048"))
+	require.Equal(t, 123, addenda10.ForeignPaymentAmount)
+
+	err := addenda10.SetForeignPaymentAmount(100, "XYZ")
+	require.ErrorIs(t, err, ErrValidISO4217)
+	require.ErrorContains(t, err, "ISODestinationCurrencyCode")
+	require.ErrorIs(t, addenda10.SetForeignPaymentAmount(-100, "EUR"), ErrNegativeAmount)
+	require.Equal(t, 123, addenda10.ForeignPaymentAmount)
+	require.NoError(t, addenda10.Validate())
+}
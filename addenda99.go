@@ -20,7 +20,10 @@ package ach
 import (
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
+
+	"github.com/moov-io/base"
 )
 
 // When a Return Entry is prepared, the original Company/Batch Header Record, the original Entry Detail Record,
@@ -325,6 +328,30 @@ func (Addenda99 *Addenda99) ReturnCodeField() *ReturnCode {
 	return nil
 }
 
+// ValidateTimeliness checks the return is made within the time frame Nacha allows for its ReturnCode,
+// counted from the settlement date of the original entry. Only the dates of both times are compared.
+//
+// Extended returns for unauthorized entries (R05, R07, R10, R11, R37, R51, R52 and R53) are allowed
+// until 60 calendar days after settlement. R06 and R31 returns depend on an agreement with the ODFI and
+// are not checked. All other codes must be returned within 2 banking days.
+func (Addenda99 *Addenda99) ValidateTimeliness(originalSettlement, returnDate time.Time) error {
+	var deadline time.Time
+	switch Addenda99.ReturnCode {
+	case "R06", "R31":
+		return nil
+	case "R05", "R07", "R10", "R11", "R37", "R51", "R52", "R53":
+		deadline = originalSettlement.AddDate(0, 0, 60)
+	default:
+		deadline = base.NewTime(originalSettlement).AddBankingDay(2).Time
+	}
+
+	returned := time.Date(returnDate.Year(), returnDate.Month(), returnDate.Day(), 0, 0, 0, 0, time.UTC)
+	if returned.After(time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)) {
+		return fieldError("ReturnCode", ErrAddenda99ReturnTimeliness, Addenda99.ReturnCode)
+	}
+	return nil
+}
+
 // LookupReturnCode will return a struct representing the reason and description for
 // the provided NACHA return code.
 func LookupReturnCode(code string) *ReturnCode {
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 	"unicode/utf8"
 
 	"github.com/moov-io/base"
@@ -139,6 +140,7 @@ func TestAddenda99AllReturnCodes(t *testing.T) {
 		ed.Amount = 0
 		ed.IdentificationNumber = ""
 		ed.Addenda99 = addenda99
+		ed.AddendaRecordIndicator = 1
 		require.NoError(t, ed.Validate())
 
 		line = ed.String()
@@ -441,3 +443,31 @@ func TestAddenda99__CustomReturnCode(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestAddenda99__ValidateTimeliness(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 15, 30, 0, 0, time.UTC)
+	}
+	addenda99 := mockAddenda99()
+
+	// Two banking days, skipping the weekend
+	settlement := day(2024, time.March, 8) // Friday
+	addenda99.ReturnCode = "R01"
+	require.NoError(t, addenda99.ValidateTimeliness(settlement, day(2024, time.March, 12)))
+	require.ErrorIs(t, addenda99.ValidateTimeliness(settlement, day(2024, time.March, 13)), ErrAddenda99ReturnTimeliness)
+
+	// and holidays
+	settlement = day(2024, time.May, 24) // Friday before Memorial Day
+	require.NoError(t, addenda99.ValidateTimeliness(settlement, day(2024, time.May, 29)))
+	require.ErrorIs(t, addenda99.ValidateTimeliness(settlement, day(2024, time.May, 30)), ErrAddenda99ReturnTimeliness)
+
+	// Unauthorized consumer returns have 60 days
+	settlement = day(2024, time.March, 8)
+	addenda99.ReturnCode = "R10"
+	require.NoError(t, addenda99.ValidateTimeliness(settlement, day(2024, time.May, 7)))
+	require.ErrorIs(t, addenda99.ValidateTimeliness(settlement, day(2024, time.May, 8)), ErrAddenda99ReturnTimeliness)
+
+	// ODFI requested returns aren't checked
+	addenda99.ReturnCode = "R06"
+	require.NoError(t, addenda99.ValidateTimeliness(settlement, day(2025, time.January, 1)))
+}
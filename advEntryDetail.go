@@ -308,3 +308,16 @@ func (ed *ADVEntryDetail) JulianDateDayField() string {
 func (ed *ADVEntryDetail) SequenceNumberField() string {
 	return ed.numericField(ed.SequenceNumber, 4)
 }
+
+// Clone returns a deep copy of the ADVEntryDetail including its Addenda99.
+func (ed *ADVEntryDetail) Clone() *ADVEntryDetail {
+	if ed == nil {
+		return nil
+	}
+	out := *ed
+	if ed.Addenda99 != nil {
+		addenda99 := *ed.Addenda99
+		out.Addenda99 = &addenda99
+	}
+	return &out
+}
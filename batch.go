@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -232,6 +233,16 @@ func NewBatch(bh *BatchHeader) (Batcher, error) {
 	return nil, NewErrFileUnknownSEC(bh.StandardEntryClassCode)
 }
 
+// NewBatchFromSEC returns a batch of the type matching the Standard Entry Class Code (e.g. "PPD", "CCD", "WEB")
+// with an otherwise empty BatchHeader. Returns an error if the SEC code is not supported.
+//
+// IAT batches are not Batchers, use NewIATBatch instead.
+func NewBatchFromSEC(sec string) (Batcher, error) {
+	bh := NewBatchHeader()
+	bh.StandardEntryClassCode = sec
+	return NewBatch(bh)
+}
+
 // ConvertBatchType will take a batch object and convert it into one of the correct batch type
 func ConvertBatchType(b Batch) Batcher {
 	switch b.Header.StandardEntryClassCode {
@@ -301,13 +312,70 @@ func (batch *Batch) Validate() error {
 	return errors.New("use an implementation of batch or NewBatch")
 }
 
+// ValidateAll checks the BatchHeader, BatchControl and every entry (including addenda records) of the
+// Batch and returns each error found instead of stopping at the first. Entry errors are wrapped in a
+// BatchError whose FieldValue is the entry's TraceNumber.
+//
+// The batch level rules shared by all SEC codes are only checked once each record is valid.
+// SEC specific rules are checked by the Validate method of each batch type.
+func (batch *Batch) ValidateAll() []error {
+	if len(batch.Entries) <= 0 && len(batch.ADVEntries) <= 0 {
+		return []error{batch.Error("entries", ErrBatchNoEntries)}
+	}
+
+	var errs []error
+	errs = appendError(errs, batch.Error("BatchHeader", batch.Header.Validate()))
+
+	if !batch.IsADV() {
+		for _, entry := range batch.Entries {
+			for _, err := range entry.ValidateAll() {
+				errs = append(errs, batch.Error("EntryDetail", err, entry.TraceNumber))
+			}
+		}
+		errs = appendError(errs, batch.Error("BatchControl", batch.Control.Validate()))
+	} else {
+		for _, entry := range batch.ADVEntries {
+			if err := entry.Validate(); err != nil {
+				errs = append(errs, batch.Error("ADVEntryDetail", err, entry.SequenceNumber))
+			}
+			if entry.Addenda99 != nil {
+				if err := entry.Addenda99.Validate(); err != nil {
+					errs = append(errs, batch.Error("ADVEntryDetail", err, entry.SequenceNumber))
+				}
+			}
+		}
+		errs = appendError(errs, batch.Error("ADVBatchControl", batch.ADVControl.Validate()))
+	}
+
+	if len(errs) == 0 {
+		errs = appendError(errs, batch.verify())
+	}
+	return errs
+}
+
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
 // the default NACHA validation rules.
+//
+// Non-nil opts are also stored on the BatchHeader, BatchControl and each EntryDetail
+// currently in the Batch.
 func (batch *Batch) SetValidation(opts *ValidateOpts) {
 	if batch == nil {
 		return
 	}
 	batch.validateOpts = opts
+
+	if opts == nil {
+		return
+	}
+	if batch.Header != nil {
+		batch.Header.SetValidation(opts)
+	}
+	if batch.Control != nil {
+		batch.Control.SetValidation(opts)
+	}
+	for i := range batch.Entries {
+		batch.Entries[i].SetValidation(opts)
+	}
 }
 
 // verify checks basic valid NACHA batch rules. Assumes properly parsed records. This does not mean it is a valid batch as validity is tied to each batch type
@@ -338,8 +406,9 @@ func (batch *Batch) verify() error {
 
 		// Control ODFIIdentification must be the same as batch header
 		if batch.Header.ODFIIdentification != batch.Control.ODFIIdentification {
-			return batch.Error("ODFIIdentification",
-				NewErrBatchHeaderControlEquality(batch.Header.ODFIIdentification, batch.Control.ODFIIdentification))
+			return batch.Error("ODFIIdentification", fieldError("ODFIIdentification",
+				NewErrBatchHeaderControlEquality(batch.Header.ODFIIdentification, batch.Control.ODFIIdentification),
+				batch.Control.ODFIIdentification))
 		}
 		// batch number header and control must match
 		if batch.Header.BatchNumber != batch.Control.BatchNumber {
@@ -354,8 +423,9 @@ func (batch *Batch) verify() error {
 		}
 		// Control ODFIIdentification must be the same as batch header
 		if batch.Header.ODFIIdentification != batch.ADVControl.ODFIIdentification {
-			return batch.Error("ODFIIdentification",
-				NewErrBatchHeaderControlEquality(batch.Header.ODFIIdentification, batch.ADVControl.ODFIIdentification))
+			return batch.Error("ODFIIdentification", fieldError("ODFIIdentification",
+				NewErrBatchHeaderControlEquality(batch.Header.ODFIIdentification, batch.ADVControl.ODFIIdentification),
+				batch.ADVControl.ODFIIdentification))
 		}
 		// batch number header and control must match
 		if batch.Header.BatchNumber != batch.ADVControl.BatchNumber {
@@ -392,12 +462,67 @@ func (batch *Batch) verify() error {
 	if err := batch.isCategory(); err != nil {
 		return err
 	}
+	if err := batch.isIndividualName(); err != nil {
+		return err
+	}
+	if batch.validateOpts != nil && batch.validateOpts.RequireCreditsOrDebitsOnly {
+		if err := batch.isSingleDirection(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isIndividualName returns an error when an entry in a consumer batch (PPD, WEB, TEL or CIE) has a
+// blank IndividualName, or one shorter than validateOpts.MinIndividualNameLength. Other SEC codes
+// store a company name or other data in the field and are not checked.
+func (batch *Batch) isIndividualName() error {
+	switch batch.Header.StandardEntryClassCode {
+	case PPD, WEB, TEL, CIE:
+	default:
+		return nil
+	}
+	minLength := 1
+	if batch.validateOpts != nil && batch.validateOpts.MinIndividualNameLength > minLength {
+		minLength = batch.validateOpts.MinIndividualNameLength
+	}
+	for _, entry := range batch.Entries {
+		name := strings.TrimSpace(entry.IndividualName)
+		if name == "" {
+			return batch.Error("IndividualName", fieldError("IndividualName", ErrFieldRequired, entry.IndividualName))
+		}
+		if utf8.RuneCountInString(name) < minLength {
+			return batch.Error("IndividualName", fieldError("IndividualName", NewErrMinFieldLength(minLength), entry.IndividualName))
+		}
+	}
+	return nil
+}
+
+// isSingleDirection returns an error when a batch contains both credit and debit entries.
+// Entries whose TransactionCode is neither a credit nor a debit are ignored.
+func (batch *Batch) isSingleDirection() error {
+	var credits, debits bool
+	for _, entry := range batch.Entries {
+		switch entry.CreditOrDebit() {
+		case "C":
+			credits = true
+		case "D":
+			debits = true
+		}
+		if credits && debits {
+			return batch.Error("TransactionCode", ErrBatchMixedCreditsAndDebits, entry.TransactionCode)
+		}
+	}
 	return nil
 }
 
 // Build creates valid batch by building sequence numbers and batch control. An error is returned if
 // the batch being built has invalid records.
 func (batch *Batch) build() error {
+	// Derive an unset ServiceClassCode from the entries, the control copies it below
+	if !batch.IsADV() && batch.Header.ServiceClassCode == 0 {
+		batch.Header.ServiceClassCode = batch.entriesServiceClassCode()
+	}
 	// Requires a valid BatchHeader
 	if err := batch.Header.Validate(); err != nil {
 		return err
@@ -435,6 +560,10 @@ func (batch *Batch) build() error {
 				}
 			}
 			seq++
+			// Clear an addenda indicator left on entries without any addenda records
+			if !entry.hasAddenda() {
+				entry.AddendaRecordIndicator = 0
+			}
 			addendaSeq := 1
 			for _, a := range entry.Addenda05 {
 				// sequences don't exist in NOC or Return addenda
@@ -453,6 +582,9 @@ func (batch *Batch) build() error {
 		bc.EntryAddendaCount = entryCount
 		bc.EntryHash = batch.calculateEntryHash()
 		bc.TotalCreditEntryDollarAmount, bc.TotalDebitEntryDollarAmount = batch.calculateBatchAmounts()
+		if batch.Control != nil {
+			bc.MessageAuthenticationCode = batch.Control.MessageAuthenticationCode
+		}
 		batch.Control = bc
 	} else {
 		for i, entry := range batch.ADVEntries {
@@ -504,6 +636,19 @@ func (batch *Batch) GetControl() *BatchControl {
 	return batch.Control
 }
 
+// SetMAC sets the MessageAuthenticationCode on the BatchControl, which is kept when the batch is built.
+// An error is returned if mac is not alphanumeric or longer than 19 characters.
+func (batch *Batch) SetMAC(mac string) error {
+	if batch.Control == nil {
+		batch.Control = NewBatchControl()
+	}
+	if err := batch.Control.validateMAC(mac); err != nil {
+		return batch.Error("MessageAuthenticationCode", err)
+	}
+	batch.Control.MessageAuthenticationCode = mac
+	return nil
+}
+
 // SetADVControl appends an BatchADVControl to the Batch
 func (batch *Batch) SetADVControl(batchADVControl *ADVBatchControl) {
 	batch.ADVControl = batchADVControl
@@ -534,6 +679,55 @@ func (batch *Batch) DeleteEntries(del func(e *EntryDetail) bool) {
 	batch.Entries = slices.DeleteFunc(batch.Entries, del)
 }
 
+// SortEntries orders the batch's entries by "trace" (TraceNumber) or "amount" (Amount, smallest first),
+// keeping the existing order of equal entries. Other orderings leave the entries unchanged.
+//
+// Entries are then given sequential TraceNumbers, keeping each entry's ODFI prefix, so they stay
+// ascending as Nacha requires. The TraceNumber of Addenda02, Addenda98 and Addenda99 records is
+// updated with their entry. This is skipped when validateOpts.CustomTraceNumbers is set.
+func (batch *Batch) SortEntries(by string) {
+	switch by {
+	case "trace":
+		slices.SortStableFunc(batch.Entries, func(a, b *EntryDetail) int {
+			return strings.Compare(a.TraceNumberField(), b.TraceNumberField())
+		})
+	case "amount":
+		slices.SortStableFunc(batch.Entries, func(a, b *EntryDetail) int {
+			return cmp.Compare(a.Amount, b.Amount)
+		})
+	default:
+		return
+	}
+
+	if batch.validateOpts != nil && batch.validateOpts.CustomTraceNumbers {
+		return
+	}
+	for i, entry := range batch.Entries {
+		entry.SetTraceNumber(entry.TraceNumberField()[:8], i+1)
+		for _, a := range entry.Addenda05 {
+			a.EntryDetailSequenceNumber = i + 1
+		}
+		if entry.Addenda02 != nil {
+			entry.Addenda02.TraceNumber = entry.TraceNumber
+		}
+		if entry.Addenda98 != nil {
+			entry.Addenda98.TraceNumber = entry.TraceNumber
+		}
+		if entry.Addenda98Refused != nil {
+			entry.Addenda98Refused.TraceNumber = entry.TraceNumber
+		}
+		if entry.Addenda99 != nil {
+			entry.Addenda99.TraceNumber = entry.TraceNumber
+		}
+		if entry.Addenda99Dishonored != nil {
+			entry.Addenda99Dishonored.TraceNumber = entry.TraceNumber
+		}
+		if entry.Addenda99Contested != nil {
+			entry.Addenda99Contested.TraceNumber = entry.TraceNumber
+		}
+	}
+}
+
 // AddADVEntry appends an ADV EntryDetail to the Batch
 func (batch *Batch) AddADVEntry(entry *ADVEntryDetail) {
 	batch.category = entry.Category
@@ -757,14 +951,16 @@ func (batch *Batch) calculateADVBatchAmounts() (credit int, debit int) {
 // be in ascending Trace Number order (although Trace Numbers need not necessarily be consecutive).
 func (batch *Batch) isSequenceAscending() error {
 	if !batch.IsADV() {
-		lastSeq := "0"
+		// compare the zero padded fields so trace numbers of differing lengths order numerically
+		lastSeq := strings.Repeat("0", 15)
 		for _, entry := range batch.Entries {
+			current := entry.TraceNumberField()
 			if batch.validateOpts == nil || !batch.validateOpts.CustomTraceNumbers {
-				if entry.TraceNumber <= lastSeq {
-					return batch.Error("TraceNumber", NewErrBatchAscending(lastSeq, entry.TraceNumber))
+				if current <= lastSeq {
+					return batch.Error("TraceNumber", NewErrBatchAscending(lastSeq, current))
 				}
 			}
-			lastSeq = entry.TraceNumber
+			lastSeq = current
 		}
 	}
 	return nil
@@ -864,7 +1060,7 @@ func (batch *Batch) isAddendaSequence() error {
 			for _, a := range entry.Addenda05 {
 				// sequences don't exist in NOC or Return addenda
 
-				if a.SequenceNumber < lastSeq {
+				if a.SequenceNumber <= lastSeq {
 					return batch.Error("SequenceNumber", NewErrBatchAscending(lastSeq, a.SequenceNumber))
 				}
 				lastSeq = a.SequenceNumber
@@ -987,7 +1183,7 @@ func (batch *Batch) addendaFieldInclusionForward(entry *EntryDetail) error {
 			return batch.Error("Addenda98", ErrBatchAddendaCategory, entry.Category)
 		}
 	}
-	if entry.Addenda99 != nil {
+	if entry.Addenda99 != nil || entry.Addenda99Dishonored != nil || entry.Addenda99Contested != nil {
 		return batch.Error("Addenda99", ErrBatchAddendaCategory, entry.Category)
 	}
 	return nil
@@ -1006,7 +1202,7 @@ func (batch *Batch) addendaFieldInclusionNOC(entry *EntryDetail) error {
 			return batch.Error("Addenda98", ErrFieldInclusion)
 		}
 	}
-	if entry.Addenda99 != nil {
+	if entry.Addenda99 != nil || entry.Addenda99Dishonored != nil || entry.Addenda99Contested != nil {
 		return batch.Error("Addenda99", ErrBatchAddendaCategory, entry.Category)
 	}
 	return nil
@@ -1086,6 +1282,11 @@ func (batch *Batch) ValidAmountForCodes(entry *EntryDetail) error {
 				if entry.TransactionCode == CheckingZeroDollarRemittanceCredit || entry.TransactionCode == SavingsZeroDollarRemittanceCredit {
 					return nil
 				}
+			case CCD, CTX:
+				// zero dollar entries can carry remittance data for checking, savings, GL and loan accounts
+				if entry.isZeroDollarRemittance(entry.TransactionCode) {
+					return nil
+				}
 			}
 
 			return fieldError("Amount", ErrBatchAmountZero, entry.Amount)
@@ -1117,16 +1318,52 @@ func (batch *Batch) ValidTranCodeForServiceClassCode(entry *EntryDetail) error {
 
 	case CreditsOnly:
 		if entry.CreditOrDebit() != "C" {
-			return batch.Error("TransactionCode", NewErrBatchServiceClassTranCode(batch.Header.ServiceClassCode, entry.TransactionCode))
+			return batch.serviceClassTranCodeError(entry)
 		}
 	case DebitsOnly:
 		if entry.CreditOrDebit() != "D" {
-			return batch.Error("TransactionCode", NewErrBatchServiceClassTranCode(batch.Header.ServiceClassCode, entry.TransactionCode))
+			return batch.serviceClassTranCodeError(entry)
 		}
 	}
 	return nil
 }
 
+// entriesServiceClassCode returns the ServiceClassCode matching the credits and debits in the batch.
+// The header's current value is kept when an entry is neither a credit nor debit.
+func (batch *Batch) entriesServiceClassCode() int {
+	var credits, debits bool
+	for _, entry := range batch.Entries {
+		if entry == nil {
+			return batch.Header.ServiceClassCode
+		}
+		switch entry.CreditOrDebit() {
+		case "C":
+			credits = true
+		case "D":
+			debits = true
+		default:
+			return batch.Header.ServiceClassCode
+		}
+	}
+	switch {
+	case credits && debits:
+		return MixedDebitsAndCredits
+	case credits:
+		return CreditsOnly
+	case debits:
+		return DebitsOnly
+	}
+	return batch.Header.ServiceClassCode
+}
+
+// serviceClassTranCodeError returns a FieldError for the header ServiceClassCode when
+// entry does not match the credits or debits it promises.
+func (batch *Batch) serviceClassTranCodeError(entry *EntryDetail) error {
+	scc := batch.Header.ServiceClassCode
+	err := NewErrBatchServiceClassTranCode(scc, entry.TransactionCode)
+	return batch.Error("TransactionCode", fieldError("ServiceClassCode", err, scc))
+}
+
 // Equal returns true only if two Batch (or any Batcher) objects are equal. Equality is determined by
 // many of the ACH Batch and EntryDetail properties.
 func (batch *Batch) Equal(other Batcher) bool {
@@ -1174,6 +1411,48 @@ func (batch *Batch) Equal(other Batcher) bool {
 	return len(batch.Entries) == equalEntries && equalEntries != 0
 }
 
+// Clone returns a deep copy of the Batch as a Batcher for the same StandardEntryClassCode.
+// The header, control records and every entry with its addenda are copied so changes to
+// the clone do not affect batch.
+func (batch *Batch) Clone() Batcher {
+	if batch == nil || batch.Header == nil {
+		return nil
+	}
+	header := *batch.Header
+	out, err := NewBatch(&header)
+	if err != nil {
+		out = &Batch{Header: &header}
+	}
+	out.SetID(batch.id)
+
+	if batch.Control != nil {
+		control := *batch.Control
+		out.SetControl(&control)
+	} else {
+		out.SetControl(nil)
+	}
+	if batch.ADVControl != nil {
+		advControl := *batch.ADVControl
+		out.SetADVControl(&advControl)
+	} else {
+		out.SetADVControl(nil)
+	}
+	for _, entry := range batch.Entries {
+		out.AddEntry(entry.Clone())
+	}
+	for _, entry := range batch.ADVEntries {
+		if entry != nil {
+			out.AddADVEntry(entry.Clone())
+		}
+	}
+	if batch.offset != nil {
+		offset := *batch.offset
+		out.WithOffset(&offset)
+	}
+	out.SetValidation(batch.validateOpts)
+	return out
+}
+
 // WithOffset sets the Offset information onto a Batch so that during Create a balanced offset record(s) at the end of each batch.
 //
 // If there are debits, there is a credit offset matching the sum of the debits. If there are credits, there is a debit offset matching
@@ -1207,7 +1486,7 @@ func (b *Batch) upsertOffsets() error {
 			}
 			// remove the EntryDetail
 			b.Control.EntryAddendaCount -= 1
-			b.Entries = append(b.Entries[:i], b.Entries[i+i:]...)
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
 			i--
 		}
 	}
@@ -1268,6 +1547,56 @@ func (b *Batch) upsertOffsets() error {
 	return nil
 }
 
+// AddOffset appends a single offset EntryDetail to the settlement account at rdfi and accountNumber
+// so the batch nets to zero. The offset is a debit when the batch has more credits than debits and a
+// credit otherwise, using checking (22/27) or savings (32/37) transaction codes. Nothing is added to
+// a batch which is already balanced.
+//
+// Unlike WithOffset the entry is added immediately and is not replaced when the batch is created again.
+func (b *Batch) AddOffset(rdfi, accountNumber string, savings bool) error {
+	if err := CheckRoutingNumber(rdfi); err != nil {
+		return fmt.Errorf("offset: invalid routing number %s: %v", rdfi, err)
+	}
+	if len(b.Entries) == 0 {
+		return b.Error("entries", ErrBatchNoEntries)
+	}
+	credit, debit := b.calculateBatchAmounts()
+	if credit == debit {
+		return nil
+	}
+
+	ed := NewEntryDetail()
+	ed.SetRDFI(rdfi)
+	ed.DFIAccountNumber = accountNumber
+	ed.IndividualName = offsetIndividualName
+	ed.Category = b.Entries[0].Category
+	ed.TraceNumber = fmt.Sprintf("%15.15d", lastTraceNumber(b.Entries)+1)
+	if credit > debit {
+		ed.Amount = credit - debit
+		ed.TransactionCode = CheckingDebit
+		if savings {
+			ed.TransactionCode = SavingsDebit
+		}
+	} else {
+		ed.Amount = debit - credit
+		ed.TransactionCode = CheckingCredit
+		if savings {
+			ed.TransactionCode = SavingsCredit
+		}
+	}
+	b.AddEntry(ed)
+	b.Header.ServiceClassCode = MixedDebitsAndCredits
+
+	// recalculate the BatchControl for the new entry
+	if b.Control != nil {
+		b.Control.ServiceClassCode = MixedDebitsAndCredits
+		b.Control.EntryAddendaCount += 1
+		b.Control.TotalCreditEntryDollarAmount, b.Control.TotalDebitEntryDollarAmount = b.calculateBatchAmounts()
+		b.Control.EntryHash = b.calculateEntryHash()
+	}
+	return nil
+}
+
 func createOffsetEntryDetail(off *Offset, batch *Batch) *EntryDetail {
 	ed := NewEntryDetail()
 	ed.RDFIIdentification = batch.offset.RoutingNumber[:8]
@@ -1294,6 +1623,11 @@ func aba8(rtn string) string {
 			return rtn[1:9] // ACH server will prefix with space, 0, or 1
 		}
 		return ""
+	case n == 0:
+		return ""
+	case n < 8:
+		// Short routing numbers are written zero padded, e.g. a dropped leading zero
+		return strings.Repeat("0", 8-n) + rtn
 	case n != 8 && n != 9:
 		return ""
 	default:
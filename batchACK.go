@@ -17,6 +17,10 @@
 
 package ach
 
+import (
+	"strconv"
+)
+
 // BatchACK is a batch file that handles SEC payment type ACK and ACK+.
 // Acknowledgement of a Corporate credit by the Receiving Depository Financial Institution (RDFI).
 // For commercial accounts only.
@@ -89,3 +93,45 @@ func (batch *BatchACK) Create() error {
 	}
 	return batch.Validate()
 }
+
+// NewACKEntryDetail returns a zero dollar acknowledgement EntryDetail for an inbound CCD credit.
+// The acknowledgement is routed back to the ODFI of the original entry and carries its trace number,
+// the Receiver's account number and the receiving company name. The TraceNumber is assigned when
+// the entry is added to a BatchACK and Create is called.
+func NewACKEntryDetail(credit *EntryDetail) (*EntryDetail, error) {
+	entry, err := newAcknowledgementEntry(credit)
+	if err != nil {
+		return nil, err
+	}
+	entry.IndividualName = credit.IndividualName
+	return entry, nil
+}
+
+// newAcknowledgementEntry builds the fields ACK and ATX entries share from the original credit
+func newAcknowledgementEntry(credit *EntryDetail) (*EntryDetail, error) {
+	if credit == nil {
+		return nil, ErrBatchTransactionCode
+	}
+	var transactionCode int
+	switch credit.TransactionCode {
+	case CheckingCredit, CheckingPrenoteCredit:
+		transactionCode = CheckingZeroDollarRemittanceCredit
+	case SavingsCredit, SavingsPrenoteCredit:
+		transactionCode = SavingsZeroDollarRemittanceCredit
+	default:
+		return nil, fieldError("TransactionCode", ErrBatchTransactionCode, credit.TransactionCode)
+	}
+	odfi := credit.TraceNumberField()[:8]
+	if n, err := strconv.Atoi(odfi); err != nil || n <= 0 {
+		return nil, fieldError("TraceNumber", ErrFieldRequired, credit.TraceNumber)
+	}
+
+	entry := NewEntryDetail()
+	entry.TransactionCode = transactionCode
+	entry.SetRDFI(odfi + strconv.Itoa(CalculateCheckDigit(odfi)))
+	entry.DFIAccountNumber = credit.DFIAccountNumber
+	entry.Amount = 0
+	entry.SetOriginalTraceNumber(credit.TraceNumberField())
+	entry.Category = CategoryForward
+	return entry, nil
+}
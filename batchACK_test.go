@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
 )
 
 // mockBatchACKHeader creates a ACK batch header
@@ -232,12 +233,14 @@ func BenchmarkBatchACKAddendaCount(b *testing.B) {
 // testBatchACKServiceClassCode validates ServiceClassCode
 func testBatchACKServiceClassCode(t testing.TB) {
 	mockBatch := mockBatchACK(t)
-	// Batch Header information is required to Create a batch.
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
 // TestBatchACKServiceClassCode tests validating ServiceClassCode
@@ -322,3 +325,33 @@ func TestBatchACKValidTranCodeForServiceClassCode(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestNewACKEntryDetail(t *testing.T) {
+	credit := mockCCDEntryDetail()
+	credit.TransactionCode = SavingsCredit
+
+	entry, err := NewACKEntryDetail(credit)
+	require.NoError(t, err)
+	require.Equal(t, SavingsZeroDollarRemittanceCredit, entry.TransactionCode)
+	require.Equal(t, credit.TraceNumberField()[:8], entry.RDFIIdentification)
+	require.Equal(t, credit.TraceNumberField(), entry.OriginalTraceNumberField())
+	require.Equal(t, credit.DFIAccountNumber, entry.DFIAccountNumber)
+	require.Equal(t, credit.IndividualName, entry.IndividualName)
+	require.Zero(t, entry.Amount)
+
+	bh := mockBatchACKHeader()
+	batch := NewBatchACK(bh)
+	batch.AddEntry(entry)
+	require.NoError(t, batch.Create())
+	require.Equal(t, bh.ODFIIdentification, entry.TraceNumberField()[:8])
+
+	// only credits can be acknowledged
+	credit.TransactionCode = CheckingDebit
+	_, err = NewACKEntryDetail(credit)
+	require.ErrorIs(t, err, ErrBatchTransactionCode)
+
+	credit.TransactionCode = CheckingCredit
+	credit.TraceNumber = ""
+	_, err = NewACKEntryDetail(credit)
+	require.ErrorIs(t, err, ErrFieldRequired)
+}
@@ -375,6 +375,7 @@ func TestBatchARCAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -388,6 +389,7 @@ func TestBatchARCAddendum99(t *testing.T) {
 	mockAddenda99 := mockAddenda99()
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -423,3 +425,20 @@ func testBatchARCMixedCreditsAndDebitsBatchControlMixedDebitsAndCredits(t testin
 func TestBatchARCMixedCreditsAndDebitsBatchControlMixedDebitsAndCredits(t *testing.T) {
 	testBatchARCMixedCreditsAndDebitsBatchControlMixedDebitsAndCredits(t)
 }
+
+func TestBatchARC__CheckSerialNumberPlacement(t *testing.T) {
+	batch := mockBatchARC(t)
+	entry := batch.GetEntries()[0]
+	entry.SetCheckSerialNumber("123879654")
+	require.NoError(t, batch.Create())
+
+	// Check Serial Number is positions 40-54 of the entry
+	line := entry.String()
+	require.Equal(t, "123879654      ", line[39:54])
+	require.Equal(t, "123879654", entry.CheckSerialNumber())
+
+	ed := NewEntryDetail()
+	ed.Parse(line)
+	require.Equal(t, "123879654", ed.CheckSerialNumber())
+	require.Equal(t, entry.CheckSerialNumberField(), ed.CheckSerialNumberField())
+}
@@ -75,7 +75,8 @@ func (batch *BatchATX) Validate() error {
 		// use 0 value if there is no Addenda records
 		addendaRecords, _ := strconv.Atoi(entry.CATXAddendaRecordsField())
 		if len(entry.Addenda05) != addendaRecords {
-			return batch.Error("AddendaCount", NewErrBatchExpectedAddendaCount(len(entry.Addenda05), addendaRecords))
+			return batch.Error("AddendaCount", fieldError("AddendaRecords",
+				NewErrBatchExpectedAddendaCount(len(entry.Addenda05), addendaRecords), entry.CATXAddendaRecordsField()))
 		}
 		// Verify the Amount is valid for SEC code and TransactionCode
 		if err := batch.ValidAmountForCodes(entry); err != nil {
@@ -107,3 +108,17 @@ func (batch *BatchATX) Create() error {
 	// ...
 	return batch.Validate()
 }
+
+// NewATXEntryDetail returns a zero dollar acknowledgement EntryDetail for an inbound CTX credit.
+// The acknowledgement is routed back to the ODFI of the original entry and carries its trace number,
+// the Receiver's account number and the receiving company name without any addenda records.
+// The TraceNumber is assigned when the entry is added to a BatchATX and Create is called.
+func NewATXEntryDetail(credit *EntryDetail) (*EntryDetail, error) {
+	entry, err := newAcknowledgementEntry(credit)
+	if err != nil {
+		return nil, err
+	}
+	entry.SetCATXAddendaRecords(0)
+	entry.SetCATXReceivingCompany(credit.CATXReceivingCompanyField())
+	return entry, nil
+}
@@ -571,3 +571,37 @@ func TestBatchATXValidTranCodeForServiceClassCode(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestNewATXEntryDetail(t *testing.T) {
+	credit := mockCTXEntryDetail()
+	credit.SetCATXReceivingCompany("Receiver Company")
+	credit.SetCATXAddendaRecords(2)
+
+	entry, err := NewATXEntryDetail(credit)
+	require.NoError(t, err)
+	require.Equal(t, CheckingZeroDollarRemittanceCredit, entry.TransactionCode)
+	require.Equal(t, credit.TraceNumberField(), entry.OriginalTraceNumberField())
+	require.Equal(t, "0000", entry.CATXAddendaRecordsField())
+	require.Equal(t, credit.CATXReceivingCompanyField(), entry.CATXReceivingCompanyField())
+
+	batch := NewBatchATX(mockBatchATXHeader())
+	batch.AddEntry(entry)
+	require.NoError(t, batch.Create())
+}
+
+// TestBatchATXAddendaRecordsMismatch validates the addenda count stored in the entry matches its Addenda05 records
+func TestBatchATXAddendaRecordsMismatch(t *testing.T) {
+	mockBatch := NewBatchATX(mockBatchATXHeader())
+	entry := mockATXEntryDetail()
+	entry.SetCATXAddendaRecords(2)
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddAddenda05(mockAddenda05())
+	mockBatch.AddEntry(entry)
+
+	err := mockBatch.Create()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecords", fe.FieldName)
+	require.Equal(t, "0002", fe.Value)
+	require.True(t, base.Match(err, NewErrBatchExpectedAddendaCount(3, 2)))
+}
@@ -375,6 +375,7 @@ func TestBatchBOCAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -389,6 +390,7 @@ func TestBatchBOCAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
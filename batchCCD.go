@@ -20,6 +20,8 @@ package ach
 // BatchCCD is a batch file that handles SEC payment type CCD and CCD+.
 // Corporate credit or debit. Identifies an Entry initiated by an Organization to transfer funds to or from an account of that Organization or another Organization.
 // For commercial accounts only.
+//
+// The IndividualName of each EntryDetail holds the Receiving Company Name, see SetReceivingCompany and ReceivingCompanyField.
 type BatchCCD struct {
 	Batch
 }
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/moov-io/base"
@@ -114,6 +115,7 @@ func TestBatchCCDAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -128,6 +130,7 @@ func TestBatchCCDAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -230,12 +233,14 @@ func BenchmarkBatchCCDAddendaCount(b *testing.B) {
 // testBatchCCDCreate creates a batch CCD
 func testBatchCCDCreate(t testing.TB) {
 	mockBatch := mockBatchCCD(t)
-	// Batch Header information is required to Create a batch.
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
 // TestBatchCCDCreate Test creating a batch CCD
@@ -296,3 +301,49 @@ func TestBatchCCDAddenda02(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchCCDZeroDollarRemittance validates zero dollar remittance entries for each account type
+func TestBatchCCDZeroDollarRemittance(t *testing.T) {
+	codes := []int{
+		CheckingZeroDollarRemittanceCredit, CheckingZeroDollarRemittanceDebit,
+		SavingsZeroDollarRemittanceCredit, SavingsZeroDollarRemittanceDebit,
+		GLZeroDollarRemittanceCredit, GLZeroDollarRemittanceDebit,
+		LoanZeroDollarRemittanceCredit,
+	}
+	for _, code := range codes {
+		bh := mockBatchCCDHeader()
+		bh.ServiceClassCode = MixedDebitsAndCredits
+		mockBatch := NewBatchCCD(bh)
+		entry := mockCCDEntryDetail()
+		entry.TransactionCode = code
+		entry.Amount = 0
+		entry.AddAddenda05(mockAddenda05())
+		entry.AddendaRecordIndicator = 1
+		mockBatch.AddEntry(entry)
+		require.NoError(t, mockBatch.Create(), "TransactionCode %d", code)
+	}
+
+	// other codes still require an amount
+	mockBatch := mockBatchCCD(t)
+	mockBatch.GetEntries()[0].Amount = 0
+	require.ErrorIs(t, mockBatch.Create(), ErrBatchAmountZero)
+}
+
+func TestBatchCCD__ReceivingCompanyRoundTrip(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchCCD(t))
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, read.Batches, 1)
+
+	batch, ok := read.Batches[0].(*BatchCCD)
+	require.True(t, ok)
+	entry := batch.GetEntries()[0]
+	require.Equal(t, "Best Co. #23          ", entry.ReceivingCompanyField())
+	require.Len(t, entry.Addenda05, 1)
+}
@@ -63,9 +63,9 @@ func (batch *BatchCIE) Validate() error {
 	}
 
 	for _, entry := range batch.Entries {
-		// CIE detail entries must be a debit
+		// CIE detail entries must be a credit
 		if entry.CreditOrDebit() != "C" {
-			return batch.Error("TransactionCode", ErrBatchDebitOnly, entry.TransactionCode)
+			return batch.Error("TransactionCode", ErrBatchCreditOnly, entry.TransactionCode)
 		}
 		// CIE must have a maximum of one Addenda05 record
 		if len(entry.Addenda05) > 1 {
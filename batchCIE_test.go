@@ -224,12 +224,12 @@ func testBatchCIETransactionCode(t testing.TB) {
 	mockBatch := mockBatchCIE(t)
 	mockBatch.GetEntries()[0].TransactionCode = CheckingDebit
 	err := mockBatch.Create()
-	if !base.Match(err, ErrBatchDebitOnly) {
+	if !base.Match(err, ErrBatchCreditOnly) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
 
-// TestBatchCIETransactionCode tests validating BatchCIE TransactionCode is not a credit
+// TestBatchCIETransactionCode tests validating BatchCIE TransactionCode is not a debit
 func TestBatchCIETransactionCode(t *testing.T) {
 	testBatchCIETransactionCode(t)
 }
@@ -395,6 +395,7 @@ func TestBatchCIEAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -409,6 +410,7 @@ func TestBatchCIEAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -452,3 +454,15 @@ func testBatchCIEMixedDebitsAndCreditsServiceClassCode(t testing.TB) {
 func TestBatchCIEMixedDebitsAndCreditsServiceClassCode(t *testing.T) {
 	testBatchCIEMixedDebitsAndCreditsServiceClassCode(t)
 }
+
+// TestBatchCIEIndividualNameRequired validates the consumer is identified on each CIE entry
+func TestBatchCIEIndividualNameRequired(t *testing.T) {
+	mockBatch := mockBatchCIE(t)
+	mockBatch.GetEntries()[0].IndividualName = "                      "
+	err := mockBatch.Create()
+	require.ErrorIs(t, err, ErrFieldRequired)
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "IndividualName", fe.FieldName)
+}
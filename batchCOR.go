@@ -97,6 +97,12 @@ func (batch *BatchCOR) Validate() error {
 // Create implementations are free to modify computable fields in a file and should
 // call the Batch's Validate function at the end of their execution.
 func (batch *BatchCOR) Create() error {
+	// COR entries are Notifications of Change, match the Category the Reader assigns
+	for _, entry := range batch.Entries {
+		if entry.Addenda98 != nil || entry.Addenda98Refused != nil {
+			entry.Category = CategoryNOC
+		}
+	}
 	// generates sequence numbers and batch control
 	if err := batch.build(); err != nil {
 		return err
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
 )
 
 // mockBatchCORHeader creates a COR BatchHeader
@@ -157,6 +158,16 @@ func BenchmarkBatchCORAddendaCountZero(b *testing.B) {
 	}
 }
 
+// TestBatchCORCreateCategory validates Create marks COR entries as Notifications of Change
+func TestBatchCORCreateCategory(t *testing.T) {
+	mockBatch := mockBatchCOR(t)
+	require.Equal(t, CategoryNOC, mockBatch.GetEntries()[0].Category)
+
+	mockBatch.GetEntries()[0].Category = CategoryForward
+	require.NoError(t, mockBatch.Create())
+	require.Equal(t, CategoryNOC, mockBatch.GetEntries()[0].Category)
+}
+
 // testBatchCORAddendaType validates that Addendum is of type Addenda98
 func testBatchCORAddendaType(t testing.TB) {
 	mockBatch := NewBatchCOR(mockBatchCORHeader())
@@ -76,7 +76,15 @@ func (batch *BatchCTX) Validate() error {
 		indicator, _ := strconv.Atoi(entry.CATXAddendaRecordsField())
 		if addendaCount != indicator {
 			if batch.validateOpts == nil || !batch.validateOpts.UnequalAddendaCounts {
-				return batch.Error("AddendaCount", NewErrBatchExpectedAddendaCount(addendaCount, indicator))
+				return batch.Error("AddendaCount", fieldError("AddendaRecords",
+					NewErrBatchExpectedAddendaCount(addendaCount, indicator), entry.CATXAddendaRecordsField()))
+			}
+		}
+		// Addenda05 SequenceNumbers must run 1..N without gaps or duplicates
+		for i, addenda := range entry.Addenda05 {
+			if addenda.SequenceNumber != i+1 {
+				return batch.Error("SequenceNumber", fieldError("SequenceNumber",
+					NewErrBatchAddendaSequence(i+1, addenda.SequenceNumber), addenda.SequenceNumber))
 			}
 		}
 		// Verify TransactionCode is valid for CTX
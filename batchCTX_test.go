@@ -504,6 +504,8 @@ func testBatchCTXPrenoteAddendaRecordsErr(t testing.TB) {
 	mockBatch.AddEntry(entry)
 	mockBatch.GetEntries()[0].AddAddenda05(mockAddenda05())
 	mockBatch.Entries[0].AddendaRecordIndicator = 1
+	// skip the entry level amount check so the CTX prenote rule is reached
+	mockBatch.SetValidation(&ValidateOpts{AllowInvalidAmounts: true})
 	err := mockBatch.Create()
 	if !base.Match(err, ErrBatchTransactionCode) {
 		t.Errorf("%T %s", err, err)
@@ -594,3 +596,79 @@ func TestBatchCTX_JSON_RoundTrip(t *testing.T) {
 		require.ElementsMatch(t, ied, red, "batch[%d]", i)
 	}
 }
+
+// TestBatchCTXAddenda05Sequence validates Create numbers each Addenda05 and Validate rejects duplicates
+func TestBatchCTXAddenda05Sequence(t *testing.T) {
+	mockBatch := mockBatchCTX(t)
+	entry := mockBatch.GetEntries()[0]
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddAddenda05(mockAddenda05())
+	entry.SetCATXAddendaRecords(3)
+	entry.AddendaRecordIndicator = 1
+	require.NoError(t, mockBatch.Create())
+
+	for i, a := range entry.Addenda05 {
+		require.Equal(t, i+1, a.SequenceNumber)
+		require.Equal(t, entry.TraceNumberField()[8:], a.EntryDetailSequenceNumberField())
+	}
+
+	// duplicate sequence numbers are not ascending
+	entry.Addenda05[2].SequenceNumber = 2
+	err := mockBatch.Validate()
+	require.ErrorContains(t, err, "SequenceNumber")
+}
+
+// TestBatchCTXAddendaSequenceContiguous validates Addenda05 SequenceNumbers run 1..N
+func TestBatchCTXAddendaSequenceContiguous(t *testing.T) {
+	mockBatch := NewBatchCTX(mockBatchCTXHeader())
+	entry := mockCTXEntryDetail()
+	entry.SetCATXAddendaRecords(3)
+	for i := 0; i < 3; i++ {
+		entry.AddAddenda05(mockAddenda05())
+	}
+	entry.AddendaRecordIndicator = 1
+	mockBatch.AddEntry(entry)
+	require.NoError(t, mockBatch.Create())
+
+	// duplicate sequence number
+	entry.Addenda05[2].SequenceNumber = 2
+	err := mockBatch.Validate()
+	require.ErrorContains(t, err, "SequenceNumber")
+
+	// a gap passes the ascending check but is not contiguous
+	entry.Addenda05[2].SequenceNumber = 4
+	err = mockBatch.Validate()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "SequenceNumber", fe.FieldName)
+	var seqErr ErrBatchAddendaSequence
+	require.ErrorAs(t, err, &seqErr)
+	require.Equal(t, 3, seqErr.Expected)
+	require.Equal(t, 4, seqErr.Found)
+
+	// Create renumbers the addenda records
+	require.NoError(t, mockBatch.Create())
+	require.Equal(t, 3, entry.Addenda05[2].SequenceNumber)
+}
+
+// TestBatchCTXAddendaRecordsMismatch validates the addenda count stored in the entry matches its Addenda05 records
+func TestBatchCTXAddendaRecordsMismatch(t *testing.T) {
+	mockBatch := NewBatchCTX(mockBatchCTXHeader())
+	entry := mockCTXEntryDetail()
+	entry.SetCATXAddendaRecords(2)
+	for i := 0; i < 3; i++ {
+		entry.AddAddenda05(mockAddenda05())
+	}
+	entry.AddendaRecordIndicator = 1
+	mockBatch.AddEntry(entry)
+
+	err := mockBatch.Create()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecords", fe.FieldName)
+	require.Equal(t, "0002", fe.Value)
+	require.True(t, base.Match(err, NewErrBatchExpectedAddendaCount(3, 2)))
+
+	mockBatch.SetValidation(&ValidateOpts{UnequalAddendaCounts: true})
+	require.NoError(t, mockBatch.Create())
+}
@@ -167,8 +167,8 @@ func (bc *BatchControl) Validate() error {
 		return fieldError("CompanyIdentification", err, bc.CompanyIdentification)
 	}
 
-	if err := bc.isAlphanumeric(bc.MessageAuthenticationCode); err != nil {
-		return fieldError("MessageAuthenticationCode", err, bc.MessageAuthenticationCode)
+	if err := bc.validateMAC(bc.MessageAuthenticationCode); err != nil {
+		return err
 	}
 
 	if err := bc.totalDebitsOverflowsField(); err != nil {
@@ -232,6 +232,17 @@ func (bc *BatchControl) CompanyIdentificationField() string {
 	return bc.alphaField(bc.CompanyIdentification, 10)
 }
 
+// validateMAC checks mac is alphanumeric and fits the 19 character MessageAuthenticationCode field
+func (bc *BatchControl) validateMAC(mac string) error {
+	if err := bc.isAlphanumeric(mac); err != nil {
+		return fieldError("MessageAuthenticationCode", err, mac)
+	}
+	if utf8.RuneCountInString(mac) > 19 {
+		return fieldError("MessageAuthenticationCode", NewErrValidFieldLength(19), mac)
+	}
+	return nil
+}
+
 // MessageAuthenticationCodeField get the MessageAuthenticationCode right padded
 func (bc *BatchControl) MessageAuthenticationCodeField() string {
 	return bc.alphaField(bc.MessageAuthenticationCode, 19)
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"bytes"
 	"math"
 	"strings"
 	"testing"
@@ -351,3 +352,26 @@ func TestBatchControl_Overflow(t *testing.T) {
 
 	require.ErrorContains(t, bc.Validate(), "does not match formatted value 036854775807")
 }
+
+func TestBatch__SetMAC(t *testing.T) {
+	batch := mockBatchPPD(t)
+	require.NoError(t, batch.SetMAC("ABCD1234"))
+	require.NoError(t, batch.Create())
+	require.Equal(t, "ABCD1234", batch.GetControl().MessageAuthenticationCode)
+
+	// Round trip through a file
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Equal(t, "ABCD1234", read.Batches[0].GetControl().MessageAuthenticationCode)
+
+	err = batch.SetMAC("12345678901234567890")
+	require.ErrorIs(t, err, NewErrValidFieldLength(19))
+	require.Error(t, batch.SetMAC("ABCD®"))
+	require.Equal(t, "ABCD1234", batch.GetControl().MessageAuthenticationCode)
+}
@@ -54,6 +54,11 @@ func (batch *BatchDNE) Validate() error {
 	if batch.Header.StandardEntryClassCode != DNE {
 		return batch.Error("StandardEntryClassCode", ErrBatchSECType, DNE)
 	}
+	switch strings.ToUpper(strings.TrimSpace(batch.Header.CompanyEntryDescription)) {
+	case "DNE", "DEATH":
+	default:
+		return batch.Error("CompanyEntryDescription", ErrBatchCompanyEntryDescriptionDNE, batch.Header.CompanyEntryDescription)
+	}
 
 	// Range over Entries
 	for _, entry := range batch.Entries {
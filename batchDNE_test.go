@@ -224,12 +224,14 @@ func BenchmarkBatchDNEAddendaCount(b *testing.B) {
 // testBatchDNEServiceClassCode validates ServiceClassCode
 func testBatchDNEServiceClassCode(t testing.TB) {
 	mockBatch := mockBatchDNE(t)
-	// Batch Header information is required to Create a batch.
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
 // TestBatchDNEServiceClassCode tests validating ServiceClassCode
@@ -301,3 +303,13 @@ func TestDNRPaymentInformation(t *testing.T) {
 	expected := `DATE OF DEATH*082824*CUSTOMER SSN*333224444*AMOUNT*123.45\`
 	require.Equal(t, expected, info.String())
 }
+
+func TestBatchDNE__CompanyEntryDescription(t *testing.T) {
+	mockBatch := mockBatchDNE(t)
+
+	mockBatch.GetHeader().CompanyEntryDescription = "DNE"
+	require.NoError(t, mockBatch.Validate())
+
+	mockBatch.GetHeader().CompanyEntryDescription = "PAYROLL"
+	require.ErrorIs(t, mockBatch.Validate(), ErrBatchCompanyEntryDescriptionDNE)
+}
@@ -80,6 +80,9 @@ func (batch *BatchENR) Validate() error {
 			return err
 		}
 		// ENR must have one Addenda05
+		if entry.Category == CategoryForward && len(entry.Addenda05) == 0 {
+			return batch.Error("Addenda05", ErrFieldInclusion)
+		}
 		// Verify Addenda* FieldInclusion based on entry.Category and batchHeader.StandardEntryClassCode
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
@@ -221,11 +221,14 @@ func BenchmarkBatchENRAddendaCount(b *testing.B) {
 // testBatchENRServiceClassCode validates ServiceClassCode
 func testBatchENRServiceClassCode(t testing.TB) {
 	mockBatch := mockBatchENR(t)
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
 // TestBatchENRServiceClassCode tests validating ServiceClassCode
@@ -390,3 +393,14 @@ func TestBatchENRAddenda02(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchENRMissingAddenda05 validates BatchENR entries must have an Addenda05
+func TestBatchENRMissingAddenda05(t *testing.T) {
+	mockBatch := mockBatchENR(t)
+	mockBatch.GetEntries()[0].Addenda05 = nil
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 0
+	err := mockBatch.Create()
+	if !base.Match(err, ErrFieldInclusion) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
@@ -35,6 +35,8 @@ var (
 	ErrBatchInvalidCardTransactionType = errors.New("invalid card transaction type")
 	// ErrBatchDebitOnly is the error given when a batch which can only have debits has a credit
 	ErrBatchDebitOnly = errors.New("this batch type does not allow credit transaction codes")
+	// ErrBatchCreditOnly is the error given when a batch which can only have credits has a debit
+	ErrBatchCreditOnly = errors.New("this batch type does not allow debit transaction codes")
 	// ErrBatchCheckSerialNumber is the error given when a batch requires check serial numbers, but it is missing
 	ErrBatchCheckSerialNumber = errors.New("this batch type requires entries to have Check Serial Numbers")
 	// ErrBatchSECType is the error given when the batch's header has the wrong SEC for its type
@@ -53,8 +55,16 @@ var (
 	ErrBatchCompanyEntryDescriptionAutoenroll = errors.New("this batch type requires that the Company Entry Description is AUTOENROLL")
 	// ErrBatchCompanyEntryDescriptionREDEPCHECK is the error given when the Company Entry Description is invalid (needs to be 'REDEPCHECK')
 	ErrBatchCompanyEntryDescriptionREDEPCHECK = errors.New("this batch type requires that the Company Entry Description is REDEPCHECK")
+	// ErrBatchCompanyEntryDescriptionDNE is the error given when the Company Entry Description is invalid (needs to be 'DNE' or 'DEATH')
+	ErrBatchCompanyEntryDescriptionDNE = errors.New("this batch type requires that the Company Entry Description is DNE or DEATH")
 	// ErrBatchAddendaCategory is the error given when the addenda isn't allowed for the batch's type and category
 	ErrBatchAddendaCategory = errors.New("this batch type does not allow this addenda for category")
+	// ErrBatchWEBPaymentType is the error given when a WEB entry has a payment type other than R (recurring) or S (single)
+	ErrBatchWEBPaymentType = errors.New("this batch type requires a payment type of R (recurring) or S (single)")
+	// ErrBatchMixedCreditsAndDebits is the error given when a batch contains both credit and debit entries
+	ErrBatchMixedCreditsAndDebits = errors.New("batch contains both credit and debit entries")
+	// ErrBatchWEBCredit is the error given when a WEB credit isn't a person-to-person (single) payment
+	ErrBatchWEBCredit = errors.New("this batch type only allows credits for person-to-person (single) payments")
 )
 
 // BatchError is an Error that describes batch validation issues
@@ -182,6 +192,26 @@ func (e ErrBatchAscending) Error() string {
 	return e.Message
 }
 
+// ErrBatchAddendaSequence is the error given when addenda sequence numbers have gaps or duplicates
+type ErrBatchAddendaSequence struct {
+	Message  string
+	Expected int
+	Found    int
+}
+
+// NewErrBatchAddendaSequence creates a new error of the ErrBatchAddendaSequence type
+func NewErrBatchAddendaSequence(expected, found int) ErrBatchAddendaSequence {
+	return ErrBatchAddendaSequence{
+		Message:  fmt.Sprintf("must be contiguous from 1, expected %v but found %v", expected, found),
+		Expected: expected,
+		Found:    found,
+	}
+}
+
+func (e ErrBatchAddendaSequence) Error() string {
+	return e.Message
+}
+
 // ErrBatchCategory is the error given when a batch has entires with two different categories
 type ErrBatchCategory struct {
 	Message   string
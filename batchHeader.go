@@ -191,7 +191,8 @@ func (bh *BatchHeader) Parse(record string) {
 			// 51-53 If the entries are PPD (credits/debits towards consumer account), use PPD.
 			// If the entries are CCD (credits/debits towards corporate account), use CCD.
 			// The difference between the 2 SEC codes are outside of the scope of this post.
-			bh.StandardEntryClassCode = reset()
+			// Some originators send lowercase or mixed-case codes, which are normalized.
+			bh.StandardEntryClassCode = strings.ToUpper(strings.TrimSpace(reset()))
 		case 63:
 			// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
 			// For example: "Payroll   "
@@ -230,7 +231,11 @@ func (bh *BatchHeader) String() string {
 
 	buf.WriteString(batchHeaderPos)
 	buf.WriteString(strconv.Itoa(bh.ServiceClassCode))
-	buf.WriteString(bh.CompanyNameField())
+	if bh.validateOpts != nil && bh.validateOpts.SanitizeNames {
+		buf.WriteString(bh.alphaField(bh.sanitizeName(bh.CompanyName), 16))
+	} else {
+		buf.WriteString(bh.CompanyNameField())
+	}
 	buf.WriteString(bh.CompanyDiscretionaryDataField())
 	buf.WriteString(bh.CompanyIdentificationField())
 	buf.WriteString(bh.StandardEntryClassCode)
@@ -309,7 +314,11 @@ func (bh *BatchHeader) Validate() error {
 		return fieldError("OriginatorStatusCode", ErrOrigStatusCode, bh.OriginatorStatusCode)
 	}
 
-	if err := bh.isAlphanumeric(bh.CompanyName); err != nil {
+	companyName := bh.CompanyName
+	if bh.validateOpts != nil && bh.validateOpts.SanitizeNames {
+		companyName = bh.sanitizeName(companyName)
+	}
+	if err := bh.isAlphanumeric(companyName); err != nil {
 		return fieldError("CompanyName", err, bh.CompanyName)
 	}
 	if err := bh.isAlphanumeric(bh.CompanyDiscretionaryData); err != nil {
@@ -318,6 +327,11 @@ func (bh *BatchHeader) Validate() error {
 	if err := bh.isAlphanumeric(bh.CompanyIdentification); err != nil {
 		return fieldError("CompanyIdentification", err, bh.CompanyIdentification)
 	}
+	if bh.validateOpts != nil && bh.validateOpts.RequireCompanyIdentificationPrefix {
+		if err := bh.isCompanyIdentification(bh.CompanyIdentification); err != nil {
+			return fieldError("CompanyIdentification", err, bh.CompanyIdentification)
+		}
+	}
 	if err := bh.isAlphanumeric(bh.CompanyEntryDescription); err != nil {
 		return fieldError("CompanyEntryDescription", err, bh.CompanyEntryDescription)
 	}
@@ -373,6 +387,17 @@ func (bh *BatchHeader) CompanyDescriptiveDateField() string {
 	return bh.alphaField(bh.CompanyDescriptiveDate, 6)
 }
 
+// SetCompanyDescriptiveDate sets CompanyDescriptiveDate to t in YYMMDD format, the date shown to Receivers.
+// The same-day "SDHHMM" convention can be set on the field directly.
+func (bh *BatchHeader) SetCompanyDescriptiveDate(t time.Time) {
+	bh.CompanyDescriptiveDate = t.Format("060102") // YYMMDD
+}
+
+// SetCompanyDescriptiveDateMonthDay sets CompanyDescriptiveDate to t in "MMM D" format (e.g. "SEP 5").
+func (bh *BatchHeader) SetCompanyDescriptiveDateMonthDay(t time.Time) {
+	bh.CompanyDescriptiveDate = strings.ToUpper(t.Format("Jan 2"))
+}
+
 // EffectiveEntryDateField get the EffectiveEntryDate in YYMMDD format
 func (bh *BatchHeader) EffectiveEntryDateField() string {
 	// ENR records require EffectiveEntryDate to be space filled. NACHA Page OR108
@@ -399,3 +424,24 @@ func (bh *BatchHeader) SettlementDateField() string {
 func (bh *BatchHeader) LiftEffectiveEntryDate() (time.Time, error) {
 	return time.Parse("060102", bh.EffectiveEntryDate) // YYMMDD
 }
+
+// ValidateEffectiveDate checks the EffectiveEntryDate is not before now and is no more
+// than maxFuture days after now. A negative maxFuture skips the future check.
+// Batches without an EffectiveEntryDate (e.g. ENR) are not checked.
+func (bh *BatchHeader) ValidateEffectiveDate(now time.Time, maxFuture int) error {
+	if strings.TrimSpace(bh.EffectiveEntryDateField()) == "" {
+		return nil
+	}
+	eff, err := bh.LiftEffectiveEntryDate()
+	if err != nil {
+		return fieldError("EffectiveEntryDate", err, bh.EffectiveEntryDate)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if eff.Before(today) {
+		return fieldError("EffectiveEntryDate", ErrEffectiveEntryDatePast, bh.EffectiveEntryDate)
+	}
+	if maxFuture >= 0 && eff.After(today.AddDate(0, 0, maxFuture)) {
+		return fieldError("EffectiveEntryDate", ErrEffectiveEntryDateFuture, bh.EffectiveEntryDate)
+	}
+	return nil
+}
@@ -18,8 +18,11 @@
 package ach
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/moov-io/base"
 
@@ -556,3 +559,128 @@ func TestBatchHeader__IsValidWithPreserveSpacesOpt(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBatchHeader__RequireCompanyIdentificationPrefix(t *testing.T) {
+	bh := mockBatchHeader()
+	bh.CompanyIdentification = "8121042882"
+	require.NoError(t, bh.Validate())
+
+	bh.SetValidation(&ValidateOpts{RequireCompanyIdentificationPrefix: true})
+	err := bh.Validate()
+	require.ErrorIs(t, err, ErrCompanyIdentificationPrefix)
+	require.Contains(t, err.Error(), "CompanyIdentification")
+
+	for _, id := range []string{"1121042882", "3ABC123456", "9USERID001"} {
+		bh.CompanyIdentification = id
+		require.NoError(t, bh.Validate(), id)
+	}
+
+	bh.CompanyIdentification = "112104288A"
+	require.ErrorIs(t, bh.Validate(), ErrCompanyIdentificationPrefix)
+
+	bh.CompanyIdentification = "9USER-ID01"
+	require.ErrorIs(t, bh.Validate(), ErrNonAlphanumeric)
+
+	bh.CompanyIdentification = "121042882"
+	require.ErrorIs(t, bh.Validate(), ErrCompanyIdentificationPrefix)
+}
+
+func TestBatchHeader__ValidateEffectiveDate(t *testing.T) {
+	now := time.Date(2024, time.March, 4, 15, 30, 0, 0, time.UTC)
+
+	bh := mockBatchHeader()
+	bh.EffectiveEntryDate = "240304"
+	require.NoError(t, bh.ValidateEffectiveDate(now, 2))
+
+	bh.EffectiveEntryDate = "240306"
+	require.NoError(t, bh.ValidateEffectiveDate(now, 2))
+
+	bh.EffectiveEntryDate = "240307"
+	err := bh.ValidateEffectiveDate(now, 2)
+	require.ErrorIs(t, err, ErrEffectiveEntryDateFuture)
+	require.Contains(t, err.Error(), "EffectiveEntryDate")
+	require.NoError(t, bh.ValidateEffectiveDate(now, -1))
+
+	bh.EffectiveEntryDate = "240303"
+	require.ErrorIs(t, bh.ValidateEffectiveDate(now, 2), ErrEffectiveEntryDatePast)
+
+	bh.EffectiveEntryDate = "241340"
+	require.Error(t, bh.ValidateEffectiveDate(now, 2))
+
+	bh.StandardEntryClassCode = ENR
+	bh.CompanyEntryDescription = "AUTOENROLL"
+	require.NoError(t, bh.ValidateEffectiveDate(now, 2))
+}
+
+func TestBatchHeader__SanitizeNames(t *testing.T) {
+	bh := mockBatchHeader()
+	bh.CompanyName = "Jöhn & Co"
+	bh.SetValidation(&ValidateOpts{SanitizeNames: true})
+	require.NoError(t, bh.Validate())
+	require.Equal(t, bh.alphaField("John & Co", 16), bh.String()[4:20])
+	require.Equal(t, "Jöhn & Co", bh.CompanyName)
+}
+
+func TestBatchHeader__SettlementDate(t *testing.T) {
+	line := "5225companyname                         origid    PPDCHECKPAYMT0000021907302111076401250000001"
+	bh := NewBatchHeader()
+	bh.Parse(line)
+	require.Equal(t, "211", bh.SettlementDate)
+	require.Equal(t, line, bh.String())
+
+	// blank and out of range Julian days are written as spaces
+	bh.SettlementDate = ""
+	require.Equal(t, "   ", bh.SettlementDateField())
+
+	bh.Parse(strings.Replace(line, "190730211", "190730999", 1))
+	require.Equal(t, "   ", bh.SettlementDate)
+}
+
+func TestBatchHeader__SetCompanyDescriptiveDate(t *testing.T) {
+	bh := mockBatchHeader()
+	bh.SetCompanyDescriptiveDate(time.Date(2024, time.September, 5, 10, 0, 0, 0, time.UTC))
+	require.Equal(t, "240905", bh.CompanyDescriptiveDate)
+	require.NoError(t, bh.Validate())
+
+	line := bh.String()
+	require.Equal(t, "240905", line[63:69])
+
+	parsed := NewBatchHeader()
+	parsed.Parse(line)
+	require.Equal(t, "240905", parsed.CompanyDescriptiveDate)
+
+	bh.SetCompanyDescriptiveDateMonthDay(time.Date(2024, time.September, 5, 10, 0, 0, 0, time.UTC))
+	require.Equal(t, "SEP 5", bh.CompanyDescriptiveDate)
+	require.Equal(t, "SEP 5 ", bh.CompanyDescriptiveDateField())
+	require.NoError(t, bh.Validate())
+
+	parsed = NewBatchHeader()
+	parsed.Parse(bh.String())
+	require.Equal(t, "SEP 5", parsed.CompanyDescriptiveDate)
+
+	bh.SetCompanyDescriptiveDateMonthDay(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, "DEC 25", bh.CompanyDescriptiveDateField())
+}
+
+func TestBatchHeader__ParseLowercaseSEC(t *testing.T) {
+	bh := NewBatchHeader()
+	bh.Parse("5225Name on Account                     121042882 ppdREG.SALARY      190625   1121042880000001")
+	require.Equal(t, PPD, bh.StandardEntryClassCode)
+	require.NoError(t, bh.Validate())
+
+	for file, sec := range map[string]string{"ppd-debit.ach": "ppd", "web-debit.ach": "Web"} {
+		bs, err := os.ReadFile(filepath.Join("test", "testdata", file))
+		require.NoError(t, err)
+
+		lines := strings.Split(string(bs), "\n")
+		for i := range lines {
+			if strings.HasPrefix(lines[i], "5") && strings.EqualFold(lines[i][50:53], sec) {
+				lines[i] = lines[i][:50] + sec + lines[i][53:]
+			}
+		}
+		f, err := NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+		require.NoError(t, err, file)
+		require.NoError(t, f.Validate(), file)
+		require.Equal(t, strings.ToUpper(sec), f.Batches[0].GetHeader().StandardEntryClassCode)
+	}
+}
@@ -71,6 +71,10 @@ func (batch *BatchMTE) Validate() error {
 			return err
 		}
 		if entry.Category == CategoryForward {
+			// MTE entries must identify the ATM the transaction originated from
+			if strings.TrimSpace(entry.Addenda02.TerminalIdentificationCode) == "" {
+				return batch.Error("TerminalIdentificationCode", ErrFieldRequired, entry.Addenda02.TerminalIdentificationCode)
+			}
 			if !usabbrev.Valid(entry.Addenda02.TerminalState) {
 				return batch.Error("TerminalState", ErrValidState, entry.Addenda02.TerminalState)
 			}
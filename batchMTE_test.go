@@ -182,12 +182,14 @@ func BenchmarkBatchMTESEC(b *testing.B) {
 // testBatchMTEServiceClassCode validates ServiceClassCode
 func testBatchMTEServiceClassCode(t testing.TB) {
 	mockBatch := mockBatchMTE(t)
-	// Batch Header information is required to Create a batch.
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
 // TestBatchMTEServiceClassCode tests validating ServiceClassCode
@@ -222,6 +224,16 @@ func TestBatchMTETerminalState(t *testing.T) {
 	}
 }
 
+// TestBatchMTETerminalIdentificationCode validates TerminalIdentificationCode is required
+func TestBatchMTETerminalIdentificationCode(t *testing.T) {
+	mockBatch := mockBatchMTE(t)
+	mockBatch.GetEntries()[0].Addenda02.TerminalIdentificationCode = ""
+	err := mockBatch.Create()
+	if !base.Match(err, ErrFieldRequired) {
+		t.Errorf("%T: %s", err, err)
+	}
+}
+
 // TestBatchMTEIndividualName validates IndividualName
 func TestBatchMTEIndividualName(t *testing.T) {
 	mockBatch := mockBatchMTE(t)
@@ -354,6 +354,7 @@ func TestBatchPOSAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -515,3 +516,18 @@ func BenchmarkBatchPOSMixedDebitsAndCredits(b *testing.B) {
 		testBatchPOSMixedDebitsAndCredits(b)
 	}
 }
+
+// TestBatchPOS__SetCardTransactionType validates the card transaction type is stored in DiscretionaryData
+func TestBatchPOS__SetCardTransactionType(t *testing.T) {
+	mockBatch := mockBatchPOS(t)
+	entry := mockBatch.GetEntries()[0]
+
+	entry.SetCardTransactionType("11")
+	require.Equal(t, "11", entry.DiscretionaryData)
+	require.Equal(t, "11", entry.CardTransactionTypeField())
+	require.NoError(t, mockBatch.Validate())
+
+	entry.SetCardTransactionType("55")
+	err := mockBatch.Validate()
+	require.ErrorIs(t, err, ErrBatchInvalidCardTransactionType)
+}
@@ -18,6 +18,7 @@
 package ach
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -147,18 +148,20 @@ func BenchmarkBatchServiceClassCodeEquality(b *testing.B) {
 	}
 }
 
-// BatchPPDCreate validates batch create for an invalid service code
+// BatchPPDCreate validates batch create derives an unset service code
 func testBatchPPDCreate(t testing.TB) {
 	mockBatch := mockBatchPPD(t)
-	// can not have default values in Batch Header to build batch
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
-// TestBatchPPDCreate tests validating batch create for an invalid service code
+// TestBatchPPDCreate tests validating batch create derives an unset service code
 func TestBatchPPDCreate(t *testing.T) {
 	testBatchPPDCreate(t)
 }
@@ -178,6 +181,7 @@ func testBatchPPDTypeCode(t testing.TB) {
 	a := mockAddenda05()
 	a.TypeCode = "63"
 	mockBatch.GetEntries()[0].AddAddenda05(a)
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -302,6 +306,7 @@ func TestBatchPPDAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -316,6 +321,7 @@ func TestBatchPPDAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -352,3 +358,35 @@ func TestBatchPPDAddenda02(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestBatchPPD__CompanyEntryDescriptionRequired(t *testing.T) {
+	mockBatch := mockBatchPPD(t)
+	mockBatch.GetHeader().CompanyEntryDescription = ""
+
+	err := mockBatch.Create()
+	require.ErrorIs(t, err, ErrConstructor)
+	require.Contains(t, err.Error(), "CompanyEntryDescription")
+}
+
+func TestBatchPPD__ReturnCategory(t *testing.T) {
+	ret, err := mockPPDEntryDetail().BuildReturn("R01")
+	require.NoError(t, err)
+
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(ret)
+	require.NoError(t, batch.Create())
+
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, read.Batches, 1)
+	require.IsType(t, &BatchPPD{}, read.Batches[0])
+	require.Equal(t, CategoryReturn, read.Batches[0].GetEntries()[0].Category)
+	require.Len(t, read.ReturnEntries, 1)
+}
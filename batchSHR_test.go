@@ -301,6 +301,7 @@ func TestBatchSHRAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -315,6 +316,7 @@ func TestBatchSHRAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -568,3 +570,16 @@ func TestBatchSHRTerminalState(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+// TestBatchSHR__ShortIdentificationNumber validates a short IdentificationNumber is
+// reported as an error rather than panicking
+func TestBatchSHR__ShortIdentificationNumber(t *testing.T) {
+	mockBatch := mockBatchSHR(t)
+	mockBatch.GetEntries()[0].IdentificationNumber = ""
+
+	require.Equal(t, "    ", mockBatch.GetEntries()[0].SHRCardExpirationDateField())
+	require.Equal(t, "           ", mockBatch.GetEntries()[0].SHRDocumentReferenceNumberField())
+
+	err := mockBatch.Validate()
+	require.ErrorIs(t, err, ErrValidMonth)
+}
@@ -80,18 +80,20 @@ func BenchmarkBatchTELHeader(b *testing.B) {
 	}
 }
 
-// testBatchTELCreate validates batch create for an invalid service code
+// testBatchTELCreate validates batch create derives an unset service code
 func testBatchTELCreate(t testing.TB) {
 	mockBatch := mockBatchTEL(t)
-	// Batch Header information is required to Create a batch.
+	// An unset ServiceClassCode is derived from the entries
 	mockBatch.GetHeader().ServiceClassCode = 0
-	err := mockBatch.Create()
-	if !base.Match(err, ErrConstructor) {
+	if err := mockBatch.Create(); err != nil {
 		t.Errorf("%T: %s", err, err)
 	}
+	if scc := mockBatch.GetHeader().ServiceClassCode; scc == 0 || scc != mockBatch.GetControl().ServiceClassCode {
+		t.Errorf("unexpected ServiceClassCode header=%d control=%d", scc, mockBatch.GetControl().ServiceClassCode)
+	}
 }
 
-// TestBatchTELCreate tests validating batch create for an invalid service code
+// TestBatchTELCreate tests validating batch create derives an unset service code
 func TestBatchTELCreate(t *testing.T) {
 	testBatchTELCreate(t)
 }
@@ -210,6 +212,7 @@ func TestBatchTELAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -224,6 +227,7 @@ func TestBatchTELAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -356,6 +356,7 @@ func TestBatchTRCAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -369,6 +370,7 @@ func TestBatchTRCAddendum99(t *testing.T) {
 	mockAddenda99 := mockAddenda99()
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -440,3 +442,25 @@ func testBatchTRCMixedDebitsAndCreditsServiceClassCode(t testing.TB) {
 func TestBatchTRCMixedDebitsAndCreditsServiceClassCode(t *testing.T) {
 	testBatchTRCMixedDebitsAndCreditsServiceClassCode(t)
 }
+
+// TestBatchTRCCheckDetailSetters validates the truncated check fields can be set in any order
+func TestBatchTRCCheckDetailSetters(t *testing.T) {
+	entry := mockTRCEntryDetail()
+	entry.SetItemResearchNumber("99887766")
+	require.Equal(t, "CHECK1", entry.ProcessControlField())
+	require.Equal(t, "99887766", entry.ItemResearchNumber())
+
+	entry = NewEntryDetail()
+	entry.SetItemResearchNumber("182726")
+	entry.SetProcessControlField("CHECK2")
+	require.Equal(t, "CHECK2", entry.ProcessControlField())
+	require.Equal(t, "182726", entry.ItemResearchNumber())
+	require.Len(t, entry.IndividualName, 22)
+
+	mockBatch := NewBatchTRC(mockBatchTRCHeader())
+	entry = mockTRCEntryDetail()
+	entry.SetProcessControlField("CHECK3")
+	mockBatch.AddEntry(entry)
+	require.NoError(t, mockBatch.Create())
+	require.Equal(t, "182726", mockBatch.GetEntries()[0].ItemResearchNumber())
+}
@@ -71,7 +71,8 @@ func (batch *BatchTRX) Validate() error {
 		// use 0 value if there is no Addenda records
 		addendaRecords, _ := strconv.Atoi(entry.CATXAddendaRecordsField())
 		if len(entry.Addenda05) != addendaRecords {
-			return batch.Error("AddendaCount", NewErrBatchExpectedAddendaCount(len(entry.Addenda05), addendaRecords))
+			return batch.Error("AddendaCount", fieldError("AddendaRecords",
+				NewErrBatchExpectedAddendaCount(len(entry.Addenda05), addendaRecords), entry.CATXAddendaRecordsField()))
 		}
 		// Verify the Amount is valid for SEC code and TransactionCode
 		if err := batch.ValidAmountForCodes(entry); err != nil {
@@ -614,3 +614,22 @@ func testBatchTRXMixedDebitsAndCreditsServiceClassCode(t testing.TB) {
 func TestBatchTRXMixedDebitsAndCreditsServiceClassCode(t *testing.T) {
 	testBatchTRXMixedDebitsAndCreditsServiceClassCode(t)
 }
+
+// TestBatchTRXAddendaRecordsMismatch validates the addenda count stored in the entry matches its Addenda05 records
+func TestBatchTRXAddendaRecordsMismatch(t *testing.T) {
+	mockBatch := NewBatchTRX(mockBatchTRXHeader())
+	entry := mockTRXEntryDetail()
+	entry.SetCATXAddendaRecords(2)
+	for i := 0; i < 3; i++ {
+		entry.AddAddenda05(mockAddenda05())
+	}
+	entry.AddendaRecordIndicator = 1
+	mockBatch.AddEntry(entry)
+
+	err := mockBatch.Create()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecords", fe.FieldName)
+	require.Equal(t, "0002", fe.Value)
+	require.True(t, base.Match(err, NewErrBatchExpectedAddendaCount(3, 2)))
+}
@@ -118,6 +118,7 @@ func TestBatchWEBAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -132,6 +133,7 @@ func TestBatchWEBAddendum99(t *testing.T) {
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryReturn
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -191,8 +193,7 @@ func testBatchWebPaymentType(t testing.TB) {
 	mockBatch := mockBatchWEB(t)
 	mockBatch.GetEntries()[0].DiscretionaryData = "AA"
 	err := mockBatch.Validate()
-	// TODO: are we expecting there to be no errors here?
-	if !base.Match(err, nil) {
+	if !base.Match(err, ErrBatchWEBPaymentType) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
@@ -318,3 +319,27 @@ func TestBatchWEBValidTranCodeForServiceClassCode(t *testing.T) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
+
+func TestBatchWEB__RequireWEBPaymentType(t *testing.T) {
+	mockBatch := mockBatchWEB(t)
+	entry := mockBatch.GetEntries()[0]
+
+	// blank payment types default to single
+	entry.DiscretionaryData = ""
+	require.NoError(t, mockBatch.Create())
+	require.Equal(t, "S", entry.DiscretionaryData)
+
+	mockBatch.SetValidation(&ValidateOpts{
+		RequireWEBPaymentType: true,
+	})
+	entry.DiscretionaryData = ""
+	require.ErrorIs(t, mockBatch.Create(), ErrBatchWEBPaymentType)
+
+	// recurring credits aren't person-to-person payments
+	entry.DiscretionaryData = "R"
+	require.ErrorIs(t, mockBatch.Validate(), ErrBatchWEBCredit)
+
+	entry.TransactionCode = CheckingDebit
+	mockBatch.GetHeader().ServiceClassCode = DebitsOnly
+	require.NoError(t, mockBatch.Create())
+}
@@ -17,6 +17,10 @@
 
 package ach
 
+import (
+	"strings"
+)
+
 // BatchWEB creates a batch file that handles SEC payment type WEB.
 // Entry submitted pursuant to an authorization obtained solely via the Internet or a wireless network
 // For consumer accounts only.
@@ -61,16 +65,52 @@ func (batch *BatchWEB) Validate() error {
 		if err := batch.addendaFieldInclusion(entry); err != nil {
 			return err
 		}
+		if err := batch.validPaymentType(entry); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// validPaymentType verifies the DiscretionaryData of a forward entry is a WEB payment type.
+// With RequireWEBPaymentType credits are only allowed as person-to-person payments, which
+// are always single entries.
+func (batch *BatchWEB) validPaymentType(entry *EntryDetail) error {
+	if entry.Category == CategoryReturn || entry.Category == CategoryNOC {
+		return nil
+	}
+	switch strings.TrimSpace(entry.DiscretionaryData) {
+	case "S":
+		return nil
+	case "R":
+		if batch.validateOpts != nil && batch.validateOpts.RequireWEBPaymentType && entry.CreditOrDebit() == "C" {
+			return batch.Error("PaymentType", ErrBatchWEBCredit, entry.DiscretionaryData)
+		}
+		return nil
+	case "":
+		if batch.validateOpts != nil && batch.validateOpts.RequireWEBPaymentType {
+			return batch.Error("PaymentType", ErrBatchWEBPaymentType, entry.DiscretionaryData)
+		}
+		return nil
+	}
+	return batch.Error("PaymentType", ErrBatchWEBPaymentType, entry.DiscretionaryData)
+}
+
 // Create will tabulate and assemble an ACH batch into a valid state. This includes
 // setting any posting dates, sequence numbers, counts, and sums.
 //
 // Create implementations are free to modify computable fields in a file and should
 // call the Batch's Validate function at the end of their execution.
 func (batch *BatchWEB) Create() error {
+	// entries without a payment type default to single
+	if batch.validateOpts == nil || !batch.validateOpts.RequireWEBPaymentType {
+		for _, entry := range batch.Entries {
+			if entry.Category != CategoryReturn && entry.Category != CategoryNOC && strings.TrimSpace(entry.DiscretionaryData) == "" {
+				entry.SetPaymentType("S")
+			}
+		}
+	}
+
 	// generates sequence numbers and batch control
 	if err := batch.build(); err != nil {
 		return err
@@ -17,6 +17,10 @@
 
 package ach
 
+import (
+	"strings"
+)
+
 // BatchXCK holds the BatchHeader and BatchControl and all EntryDetail for XCK Entries.
 //
 // Destroyed Check Entry identifies a debit entry initiated for a XCK eligible items.
@@ -63,11 +67,15 @@ func (batch *BatchXCK) Validate() error {
 		if entry.Amount > 250000 {
 			return batch.Error("Amount", NewErrBatchAmount(entry.Amount, 250000))
 		}
-		// ProcessControlField underlying IdentificationNumber, must be defined
+		// CheckSerialNumber underlying IdentificationNumber, must be defined
+		if strings.TrimSpace(entry.IdentificationNumber) == "" {
+			return batch.Error("CheckSerialNumber", ErrBatchCheckSerialNumber)
+		}
+		// ProcessControlField underlying IndividualName, must be defined
 		if entry.ProcessControlField() == "" {
 			return batch.Error("ProcessControlField", ErrFieldRequired)
 		}
-		// ItemResearchNumber underlying IdentificationNumber, must be defined
+		// ItemResearchNumber underlying IndividualName, must be defined
 		if entry.ItemResearchNumber() == "" {
 			return batch.Error("ItemResearchNumber", ErrFieldRequired)
 		}
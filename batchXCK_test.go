@@ -252,14 +252,20 @@ func BenchmarkBatchXCKAutomatedAccountingAdvices(b *testing.B) {
 	}
 }
 
-// testBatchXCKCheckSerialNumber validates BatchXCK CheckSerialNumber is not mandatory
+// testBatchXCKCheckSerialNumber validates BatchXCK CheckSerialNumber is mandatory
 func testBatchXCKCheckSerialNumber(t testing.TB) {
 	mockBatch := mockBatchXCK(t)
 	// modify CheckSerialNumber / IdentificationNumber to nothing
 	mockBatch.GetEntries()[0].SetCheckSerialNumber("")
 	err := mockBatch.Validate()
-	// no error expected
-	if !base.Match(err, nil) {
+	if !base.Match(err, ErrBatchCheckSerialNumber) {
+		t.Errorf("%T: %s", err, err)
+	}
+
+	// a blank CheckSerialNumber is also rejected
+	mockBatch.GetEntries()[0].IdentificationNumber = "               "
+	err = mockBatch.Validate()
+	if !base.Match(err, ErrBatchCheckSerialNumber) {
 		t.Errorf("%T: %s", err, err)
 	}
 }
@@ -356,6 +362,7 @@ func TestBatchXCKAddendum98(t *testing.T) {
 	mockAddenda98.TypeCode = "05"
 	mockBatch.GetEntries()[0].Category = CategoryNOC
 	mockBatch.GetEntries()[0].Addenda98 = mockAddenda98
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -369,6 +376,7 @@ func TestBatchXCKAddendum99(t *testing.T) {
 	mockAddenda99 := mockAddenda99()
 	mockAddenda99.TypeCode = "05"
 	mockBatch.GetEntries()[0].Addenda99 = mockAddenda99
+	mockBatch.GetEntries()[0].AddendaRecordIndicator = 1
 	err := mockBatch.Create()
 	if !base.Match(err, ErrAddendaTypeCode) {
 		t.Errorf("%T: %s", err, err)
@@ -20,6 +20,7 @@ package ach
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -284,6 +285,8 @@ func testBatchDNEMismatch(t testing.TB) {
 	ed := mockBatch.GetEntries()[0]
 	ed.AddAddenda05(mockAddenda05())
 	ed.AddAddenda05(mockAddenda05())
+	ed.AddendaRecordIndicator = 1
+	ed.Amount = 0
 	mockBatch.build()
 
 	mockBatch.GetHeader().OriginatorStatusCode = 1
@@ -344,6 +347,7 @@ func testBatchEntryCountEquality(t testing.TB) {
 	e := mockEntryDetail()
 	a := mockAddenda05()
 	e.AddAddenda05(a)
+	e.AddendaRecordIndicator = 1
 	mockBatch.AddEntry(e)
 	if err := mockBatch.build(); err != nil {
 		t.Errorf("%T: %s", err, err)
@@ -491,6 +495,23 @@ func BenchmarkNewBatchDefault(b *testing.B) {
 	}
 }
 
+func TestNewBatchFromSEC(t *testing.T) {
+	batch, err := NewBatchFromSEC(PPD)
+	require.NoError(t, err)
+	require.IsType(t, &BatchPPD{}, batch)
+	require.Equal(t, PPD, batch.GetHeader().StandardEntryClassCode)
+
+	batch, err = NewBatchFromSEC(CTX)
+	require.NoError(t, err)
+	require.IsType(t, &BatchCTX{}, batch)
+
+	_, err = NewBatchFromSEC(IAT)
+	require.ErrorIs(t, err, ErrFileIATSEC)
+
+	_, err = NewBatchFromSEC("ZZZ")
+	require.Equal(t, NewErrFileUnknownSEC("ZZZ"), err)
+}
+
 // testBatchCategory validates Batch Category
 func testBatchCategory(t testing.TB) {
 	mockBatch := mockBatch(t)
@@ -1312,6 +1333,39 @@ func TestBatchABA8(t *testing.T) {
 	if v := aba8("2123456789"); v != "" {
 		t.Errorf("got %s", v)
 	}
+	// dropped leading zero
+	if v := aba8("3130001"); v != "03130001" {
+		t.Errorf("got %s", v)
+	}
+}
+
+func TestBatch__EntryHashShortRDFI(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.GetEntries()[0].RDFIIdentification = "3130001"
+	batch.GetEntries()[0].CheckDigit = strconv.Itoa(CalculateCheckDigit("03130001"))
+	require.NoError(t, batch.Create())
+	require.Equal(t, 3130001, batch.GetControl().EntryHash)
+
+	// the written RDFI is zero padded and must hash the same after reading
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.NoError(t, read.Validate())
+	require.Equal(t, 3130001, read.Batches[0].GetControl().EntryHash)
+}
+
+func TestBatch__EntryHashStale(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.GetEntries()[0].SetRDFI("031300012")
+	require.ErrorContains(t, batch.Validate(), "EntryHash")
+
+	require.NoError(t, batch.Create())
+	require.NoError(t, batch.Validate())
 }
 
 func TestBatch__lastTraceNumber(t *testing.T) {
@@ -1559,6 +1613,47 @@ func TestBatch__upsertOffsetIdempotent(t *testing.T) {
 	}
 }
 
+func TestBatch__AddOffset(t *testing.T) {
+	b := mockBatchPPD(t)
+	b.Header.ServiceClassCode = MixedDebitsAndCredits
+	debit := mockPPDEntryDetail()
+	debit.TransactionCode = CheckingDebit
+	debit.Amount = 25000
+	debit.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, 2)
+	b.AddEntry(debit)
+	require.NoError(t, b.Create())
+
+	// credits exceed debits so a single checking debit is added
+	require.NoError(t, b.AddOffset("121042882", "987654321", false))
+	require.Len(t, b.Entries, 3)
+	off := b.Entries[2]
+	require.Equal(t, CheckingDebit, off.TransactionCode)
+	require.Equal(t, 100000000-25000, off.Amount)
+	require.Equal(t, "12104288", off.RDFIIdentification)
+	require.Equal(t, "987654321", off.DFIAccountNumber)
+	require.Equal(t, b.Control.TotalCreditEntryDollarAmount, b.Control.TotalDebitEntryDollarAmount)
+	require.Equal(t, 3, b.Control.EntryAddendaCount)
+	require.NoError(t, b.Validate())
+
+	// a balanced batch is left alone
+	require.NoError(t, b.AddOffset("121042882", "987654321", false))
+	require.Len(t, b.Entries, 3)
+
+	// debits exceed credits so a savings credit is added
+	b = mockBatchPPD(t)
+	b.Header.ServiceClassCode = MixedDebitsAndCredits
+	b.Entries[0].TransactionCode = CheckingDebit
+	require.NoError(t, b.Create())
+	require.NoError(t, b.AddOffset("121042882", "987654321", true))
+	require.Len(t, b.Entries, 2)
+	require.Equal(t, SavingsCredit, b.Entries[1].TransactionCode)
+	require.Equal(t, b.Control.TotalCreditEntryDollarAmount, b.Control.TotalDebitEntryDollarAmount)
+	require.NoError(t, b.Create())
+	require.Equal(t, b.Control.TotalCreditEntryDollarAmount, b.Control.TotalDebitEntryDollarAmount)
+
+	require.ErrorContains(t, b.AddOffset("1", "987654321", true), "offset: invalid routing number")
+}
+
 func TestBatch__upsertOffsetsErr(t *testing.T) {
 	f := mockFilePPD(t)
 	b, ok := f.Batches[0].(*BatchPPD)
@@ -1771,3 +1866,399 @@ func TestBatch_DeleteADVEntries(t *testing.T) {
 	require.Len(t, b.ADVEntries, 1)
 	require.Equal(t, "1", b.ADVEntries[0].ID)
 }
+
+func TestBatch__CalculateBalancedOffsetCreateTwice(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = MixedDebitsAndCredits
+	b := NewBatchPPD(bh)
+	for i := 0; i < 3; i++ {
+		ed := mockPPDEntryDetail()
+		ed.TransactionCode = CheckingDebit
+		ed.SetTraceNumber(bh.ODFIIdentification, i+1)
+		b.AddEntry(ed)
+	}
+	b.WithOffset(&Offset{
+		RoutingNumber: "121042882",
+		AccountNumber: "123456789",
+		AccountType:   OffsetChecking,
+		Description:   "test offset",
+	})
+
+	require.NoError(t, b.Create())
+	require.Len(t, b.Entries, 4)
+
+	// Creating the batch again replaces the existing offset
+	require.NoError(t, b.Create())
+	require.Len(t, b.Entries, 4)
+	require.Equal(t, offsetIndividualName, b.Entries[3].IndividualName)
+	require.Equal(t, b.Control.TotalDebitEntryDollarAmount, b.Control.TotalCreditEntryDollarAmount)
+	require.Equal(t, 4, b.Control.EntryAddendaCount)
+}
+
+func TestBatch_ValidateAll(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.AddEntry(mockPPDEntryDetail())
+	batch.Entries[1].SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, 2)
+	require.NoError(t, batch.Create())
+	require.Empty(t, batch.ValidateAll())
+
+	batch.Entries[0].DFIAccountNumber = "®"
+	batch.Entries[1].Amount = -1
+	batch.Header.CompanyName = ""
+
+	errs := batch.ValidateAll()
+	require.Len(t, errs, 3)
+	require.ErrorIs(t, errs[0], ErrConstructor)
+
+	var be *BatchError
+	require.ErrorAs(t, errs[1], &be)
+	require.Equal(t, "EntryDetail", be.FieldName)
+	require.Equal(t, batch.Entries[0].TraceNumber, be.FieldValue)
+	require.ErrorIs(t, errs[1], ErrNonAlphanumeric)
+
+	require.ErrorAs(t, errs[2], &be)
+	require.Equal(t, batch.Entries[1].TraceNumber, be.FieldValue)
+	require.ErrorIs(t, errs[2], ErrNegativeAmount)
+}
+
+func TestBatch_ValidateAllBatchRules(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.Control.EntryAddendaCount = 5
+
+	errs := batch.ValidateAll()
+	require.Len(t, errs, 1)
+	require.Equal(t, batch.verify().Error(), errs[0].Error())
+}
+
+func TestBatch__TamperedControlTotals(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	tamper := func(field string, start int) {
+		t.Helper()
+		lines := strings.Split(string(bs), "\n")
+		for i := range lines {
+			if strings.HasPrefix(lines[i], "8") {
+				lines[i] = lines[i][:start] + "000000000001" + lines[i][start+12:]
+			}
+		}
+		_, errs := NewReader(strings.NewReader(strings.Join(lines, "\n"))).ReadWithErrors()
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], field)
+
+		var controlErr ErrBatchCalculatedControlEquality
+		require.ErrorAs(t, errs[0], &controlErr)
+		require.Equal(t, 1, controlErr.ControlValue)
+	}
+	tamper("TotalDebitEntryDollarAmount", 20)
+	tamper("TotalCreditEntryDollarAmount", 32)
+}
+
+func TestBatch__Clone(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.GetEntries()[0].AddAddenda05(mockAddenda05())
+	batch.GetEntries()[0].AddendaRecordIndicator = 1
+	require.NoError(t, batch.Create())
+
+	clone := batch.Clone()
+	require.IsType(t, &BatchPPD{}, clone)
+	require.True(t, batch.Equal(clone))
+	require.NoError(t, clone.Validate())
+
+	clone.GetHeader().CompanyName = "Other Co"
+	clone.GetControl().TotalCreditEntryDollarAmount = 1
+	clone.GetEntries()[0].Amount = 1
+	clone.GetEntries()[0].Addenda05[0].PaymentRelatedInformation = "changed"
+
+	require.Equal(t, "ACME Corporation", batch.GetHeader().CompanyName)
+	require.Equal(t, 100000000, batch.GetControl().TotalCreditEntryDollarAmount)
+	require.Equal(t, 100000000, batch.GetEntries()[0].Amount)
+	require.NotEqual(t, "changed", batch.GetEntries()[0].Addenda05[0].PaymentRelatedInformation)
+	require.NoError(t, batch.Validate())
+
+	adv := mockBatchADV(t)
+	advClone := adv.Clone()
+	require.IsType(t, &BatchADV{}, advClone)
+	advClone.GetADVEntries()[0].Amount = 1
+	require.NotEqual(t, 1, adv.GetADVEntries()[0].Amount)
+	require.NoError(t, advClone.GetADVControl().Validate())
+
+	var nilBatch *Batch
+	require.Nil(t, nilBatch.Clone())
+}
+
+func TestBatch__TraceNumbersAscendingPadded(t *testing.T) {
+	mockBatch := mockBatch(t)
+	entry := mockEntryDetail()
+	entry.TraceNumber = "121042880000010"
+	mockBatch.AddEntry(entry)
+	require.NoError(t, mockBatch.build())
+
+	// an unpadded trace number is compared numerically with the others
+	mockBatch.GetEntries()[0].TraceNumber = "12104288000009"
+	require.NoError(t, mockBatch.isSequenceAscending())
+
+	mockBatch.GetEntries()[0].TraceNumber = "121042880000011"
+	require.ErrorAs(t, mockBatch.isSequenceAscending(), &ErrBatchAscending{})
+}
+
+func TestBatch__CreateClearsAddendaRecordIndicator(t *testing.T) {
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(mockPPDEntryDetail())
+	batch.GetEntries()[0].AddendaRecordIndicator = 1
+	require.NoError(t, batch.Create())
+	require.Equal(t, 0, batch.GetEntries()[0].AddendaRecordIndicator)
+
+	// an entry with addenda but no indicator is still rejected
+	batch.GetEntries()[0].AddAddenda05(mockAddenda05())
+	require.ErrorIs(t, batch.Create(), ErrBatchAddendaIndicator)
+}
+
+func TestBatch__AddendaTypesBySEC(t *testing.T) {
+	// PPD entries can only carry Addenda05
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	entry := mockPPDEntryDetail()
+	entry.Addenda02 = mockAddenda02()
+	entry.AddendaRecordIndicator = 1
+	batch.AddEntry(entry)
+	require.ErrorIs(t, batch.Create(), ErrBatchAddendaCategory)
+
+	entry.Addenda02 = nil
+	entry.AddAddenda05(mockAddenda05())
+	require.NoError(t, batch.Create())
+
+	// Forward entries can't carry return addenda
+	entry.Addenda05 = nil
+	entry.Addenda99Dishonored = mockAddenda99Dishonored()
+	require.ErrorIs(t, batch.Create(), ErrBatchAddendaCategory)
+
+	// POS entries require an Addenda02
+	pos := mockBatchPOS(t)
+	require.NoError(t, pos.Validate())
+	pos.GetEntries()[0].AddAddenda05(mockAddenda05())
+	require.ErrorIs(t, pos.Create(), ErrBatchAddendaCategory)
+}
+
+func TestBatch__RequireCreditsOrDebitsOnly(t *testing.T) {
+	batch := mockBatchPPD(t)
+	debit := mockPPDEntryDetail()
+	debit.TransactionCode = CheckingDebit
+	debit.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, 2)
+	batch.AddEntry(debit)
+	batch.GetHeader().ServiceClassCode = MixedDebitsAndCredits
+	require.NoError(t, batch.Create())
+	require.NoError(t, batch.Validate())
+
+	batch.SetValidation(&ValidateOpts{RequireCreditsOrDebitsOnly: true})
+	require.ErrorIs(t, batch.Validate(), ErrBatchMixedCreditsAndDebits)
+
+	// Prenote credits count as credits
+	batch = mockBatchPPD(t)
+	batch.SetValidation(&ValidateOpts{RequireCreditsOrDebitsOnly: true})
+	prenote, err := mockPPDEntryDetail().ToPrenote()
+	require.NoError(t, err)
+	prenote.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, 2)
+	batch.AddEntry(prenote)
+	require.NoError(t, batch.Create())
+	require.NoError(t, batch.Validate())
+}
+
+func TestBatch__ServiceClassCodeConsistency(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = CreditsOnly
+	ed := mockPPDEntryDetail()
+	ed.TransactionCode = CheckingDebit
+
+	batch, err := NewBatch(bh)
+	require.NoError(t, err)
+	batch.AddEntry(ed)
+
+	err = batch.Create()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "ServiceClassCode", fe.FieldName)
+	require.Equal(t, ErrCodeServiceClass, fe.Code)
+	require.True(t, base.Match(err, ErrBatchServiceClassTranCode{}))
+
+	batch.GetHeader().ServiceClassCode = DebitsOnly
+	require.NoError(t, batch.Create())
+
+	// Mixed entries need 200
+	credit := mockPPDEntryDetail()
+	credit.SetTraceNumber(bh.ODFIIdentification, 2)
+	batch.AddEntry(credit)
+	require.ErrorAs(t, batch.Create(), &fe)
+
+	batch.GetHeader().ServiceClassCode = MixedDebitsAndCredits
+	require.NoError(t, batch.Create())
+}
+
+func TestBatch__CreateDerivesServiceClassCode(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = 0
+
+	batch, err := NewBatch(bh)
+	require.NoError(t, err)
+
+	debit := mockPPDEntryDetail()
+	debit.TransactionCode = CheckingDebit
+	batch.AddEntry(debit)
+	require.NoError(t, batch.Create())
+	require.Equal(t, DebitsOnly, batch.GetHeader().ServiceClassCode)
+	require.Equal(t, DebitsOnly, batch.GetControl().ServiceClassCode)
+
+	// Mixed entries are derived again once the code is cleared
+	credit := mockPPDEntryDetail()
+	credit.SetTraceNumber(bh.ODFIIdentification, 2)
+	batch.AddEntry(credit)
+	batch.GetHeader().ServiceClassCode = 0
+	require.NoError(t, batch.Create())
+	require.Equal(t, MixedDebitsAndCredits, batch.GetHeader().ServiceClassCode)
+	require.Equal(t, MixedDebitsAndCredits, batch.GetControl().ServiceClassCode)
+
+	// An explicit code is left for validation
+	batch.GetHeader().ServiceClassCode = CreditsOnly
+	require.Error(t, batch.Create())
+}
+
+func TestBatch__ODFIIdentificationMismatch(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	// Change the BatchControl ODFIIdentification (positions 80-87)
+	lines := strings.Split(string(bs), "\n")
+	for i := range lines {
+		if strings.HasPrefix(lines[i], "8") {
+			lines[i] = lines[i][:79] + "23138010" + lines[i][87:]
+		}
+	}
+	_, err = NewReader(strings.NewReader(strings.Join(lines, "\n"))).Read()
+	require.ErrorContains(t, err, "ODFIIdentification")
+
+	var el base.ErrorList
+	require.ErrorAs(t, err, &el)
+	var fe *FieldError
+	require.ErrorAs(t, el[0], &fe)
+	require.Equal(t, "ODFIIdentification", fe.FieldName)
+	require.Equal(t, "23138010", fe.Value)
+
+	// Create copies the header value onto the control
+	batch := mockBatchPPD(t)
+	batch.GetControl().ODFIIdentification = "23138010"
+	require.Error(t, batch.Validate())
+	require.NoError(t, batch.Create())
+	require.Equal(t, batch.GetHeader().ODFIIdentification, batch.GetControl().ODFIIdentification)
+}
+
+func TestBatch__SortEntries(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	batch := NewBatchPPD(bh)
+	for i, seq := range []int{5, 2, 9} {
+		entry := mockPPDEntryDetail()
+		entry.Amount = []int{300, 100, 200}[i]
+		entry.SetTraceNumber(bh.ODFIIdentification, seq)
+		entry.AddAddenda05(mockAddenda05())
+		entry.AddendaRecordIndicator = 1
+		batch.AddEntry(entry)
+	}
+
+	batch.SortEntries("trace")
+	var amounts []int
+	for i, entry := range batch.GetEntries() {
+		amounts = append(amounts, entry.Amount)
+		require.Equal(t, fmt.Sprintf("%s%07d", bh.ODFIIdentification, i+1), entry.TraceNumber)
+		require.Equal(t, i+1, entry.Addenda05[0].EntryDetailSequenceNumber)
+	}
+	require.Equal(t, []int{100, 300, 200}, amounts)
+	require.NoError(t, batch.Create())
+
+	batch.SortEntries("amount")
+	amounts = nil
+	for i, entry := range batch.GetEntries() {
+		amounts = append(amounts, entry.Amount)
+		require.Equal(t, fmt.Sprintf("%s%07d", bh.ODFIIdentification, i+1), entry.TraceNumber)
+	}
+	require.Equal(t, []int{100, 200, 300}, amounts)
+	require.NoError(t, batch.Validate())
+
+	// Unknown orderings make no changes
+	batch.SortEntries("name")
+	require.Equal(t, 100, batch.GetEntries()[0].Amount)
+
+	// Custom trace numbers are kept
+	batch.SetValidation(&ValidateOpts{CustomTraceNumbers: true})
+	batch.GetEntries()[0].TraceNumber = "121042880000099"
+	batch.SortEntries("trace")
+	require.Equal(t, "121042880000099", batch.GetEntries()[2].TraceNumber)
+}
+
+func TestBatch__SortEntriesAddendaTraceNumbers(t *testing.T) {
+	// Addenda02 records follow their entry
+	pos := NewBatchPOS(mockBatchPOSHeader())
+	for i, seq := range []int{5, 2} {
+		entry := mockPOSEntryDetail()
+		entry.Amount = []int{300, 100}[i]
+		entry.SetTraceNumber(pos.GetHeader().ODFIIdentification, seq)
+		entry.Addenda02 = mockAddenda02()
+		entry.Addenda02.TraceNumber = entry.TraceNumber
+		entry.AddendaRecordIndicator = 1
+		pos.AddEntry(entry)
+	}
+	pos.SortEntries("amount")
+	for _, entry := range pos.GetEntries() {
+		require.Equal(t, entry.TraceNumber, entry.Addenda02.TraceNumber)
+	}
+	require.Equal(t, 100, pos.GetEntries()[0].Amount)
+	require.NoError(t, pos.Create())
+
+	// Addenda99 records on returns follow their entry
+	ret := NewBatchPPD(mockBatchPPDHeader())
+	for i, seq := range []int{7, 3} {
+		entry := mockPPDEntryDetail()
+		entry.Amount = []int{300, 100}[i]
+		entry.SetTraceNumber(ret.GetHeader().ODFIIdentification, seq)
+		entry.Category = CategoryReturn
+		entry.Addenda99 = mockAddenda99()
+		entry.Addenda99.TraceNumber = entry.TraceNumber
+		entry.AddendaRecordIndicator = 1
+		ret.AddEntry(entry)
+	}
+	ret.SortEntries("trace")
+	for i, entry := range ret.GetEntries() {
+		require.Equal(t, fmt.Sprintf("%s%07d", ret.GetHeader().ODFIIdentification, i+1), entry.TraceNumber)
+		require.Equal(t, entry.TraceNumber, entry.Addenda99.TraceNumber)
+	}
+	require.Equal(t, 100, ret.GetEntries()[0].Amount)
+	require.NoError(t, ret.Create())
+}
+
+func TestBatch__IndividualNameRequired(t *testing.T) {
+	batch := mockBatchPPD(t)
+	batch.GetEntries()[0].IndividualName = strings.Repeat(" ", 22)
+
+	err := batch.Create()
+	require.ErrorIs(t, err, ErrFieldRequired)
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "IndividualName", fe.FieldName)
+
+	// Minimum length is only enforced when requested
+	batch.GetEntries()[0].IndividualName = "Al"
+	require.NoError(t, batch.Validate())
+
+	batch.SetValidation(&ValidateOpts{MinIndividualNameLength: 3})
+	err = batch.Validate()
+	var minLength ErrMinFieldLength
+	require.ErrorAs(t, err, &minLength)
+	require.Equal(t, 3, minLength.MinLength)
+
+	batch.GetEntries()[0].IndividualName = "Ann  "
+	require.NoError(t, batch.Validate())
+
+	// CCD carries the receiving company name which is optional
+	ccd := mockBatchCCD(t)
+	ccd.GetEntries()[0].IndividualName = strings.Repeat(" ", 22)
+	ccd.SetValidation(&ValidateOpts{MinIndividualNameLength: 3})
+	require.NoError(t, ccd.Create())
+	require.NoError(t, ccd.Validate())
+}
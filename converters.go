@@ -18,10 +18,14 @@
 package ach
 
 import (
+	"bytes"
 	"math"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // converters handles golang to ACH type Converters
@@ -114,27 +118,23 @@ func (c *converters) numericField(n int, max uint) string {
 		return ""
 	}
 
-	s := strconv.FormatInt(int64(n), 10)
-	l := uint(len(s))
+	// Format the digits and padding on the stack so only the returned string is allocated
+	var num [20]byte
+	digits := strconv.AppendInt(num[:0], int64(n), 10)
+	l := uint(len(digits))
 
 	// Truncate if the length exceeds max
 	if l > max {
-		return s[l-max:]
-	}
-
-	m := int(max) - int(l) //nolint:gosec
-	if m < 0 {
-		return ""
+		return string(digits[l-max:])
 	}
 
-	// Pad with preallocated string if available
-	pad, exists := stringZeros[m]
-	if exists {
-		return pad + s
+	var out [lineLength]byte
+	m := max - l
+	for i := uint(0); i < m; i++ {
+		out[i] = '0'
 	}
-
-	// Slow path: Pad with "0" if no preallocated string found
-	return strings.Repeat("0", m) + s
+	copy(out[m:], digits)
+	return string(out[:max])
 }
 
 // stringField slices to max length and zero filled
@@ -170,6 +170,61 @@ func (c *converters) stringField(s string, max uint) string {
 	return strings.Repeat("0", m) + s
 }
 
+// padding returns n copies of zero, preferring the preallocated strings in pads.
+func padding(pads map[int]string, n int, zero string) string {
+	if pad, exists := pads[n]; exists {
+		return pad
+	}
+	return strings.Repeat(zero, n)
+}
+
+// writeAlphaField writes s to buf as alphaField would format it, without allocating a new string
+// for the padded value.
+func (c *converters) writeAlphaField(buf *bytes.Buffer, s string, max uint) {
+	if max > lineLength {
+		return
+	}
+	ln := uint(utf8.RuneCountInString(s))
+	if ln > max {
+		buf.WriteString(c.alphaField(s, max)) // truncation is rare, reuse the slow path
+		return
+	}
+	buf.WriteString(s)
+	buf.WriteString(padding(spaceZeros, int(max-ln), " ")) //nolint:gosec
+}
+
+// writeNumericField writes n to buf as numericField would format it, without allocating a new string
+// for the padded value.
+func (c *converters) writeNumericField(buf *bytes.Buffer, n int, max uint) {
+	if max > lineLength {
+		return
+	}
+	var arr [20]byte
+	digits := strconv.AppendInt(arr[:0], int64(n), 10)
+	l := uint(len(digits))
+	if l > max {
+		buf.Write(digits[l-max:])
+		return
+	}
+	buf.WriteString(padding(stringZeros, int(max-l), "0")) //nolint:gosec
+	buf.Write(digits)
+}
+
+// writeStringField writes s to buf as stringField would format it, without allocating a new string
+// for the padded value.
+func (c *converters) writeStringField(buf *bytes.Buffer, s string, max uint) {
+	if max > lineLength {
+		return
+	}
+	ln := uint(utf8.RuneCountInString(s))
+	if ln > max {
+		buf.WriteString(c.stringField(s, max))
+		return
+	}
+	buf.WriteString(padding(stringZeros, int(max-ln), "0")) //nolint:gosec
+	buf.WriteString(s)
+}
+
 // leastSignificantDigits returns the least significant digits of v limited by maxDigits.
 func (c *converters) leastSignificantDigits(v int, maxDigits uint) int {
 	if maxDigits > lineLength {
@@ -177,3 +232,27 @@ func (c *converters) leastSignificantDigits(v int, maxDigits uint) int {
 	}
 	return v % int(math.Pow10(int(maxDigits)))
 }
+
+// sanitizeName transliterates accented letters to ASCII (e.g. ö to o) and replaces any remaining
+// character which isAlphanumeric rejects with a space, so punctuation such as & ' - , . / is kept.
+// Each character is replaced one-for-one so positional data stored in a field (such as TRC and CTX
+// layouts of IndividualName) doesn't move.
+func (c *converters) sanitizeName(s string) string {
+	var v validator
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			// keep the base letter of accented characters
+			if d := []rune(norm.NFD.String(string(r))); len(d) > 0 {
+				r = d[0]
+			}
+		}
+		if err := v.isAlphanumeric(string(r)); err != nil {
+			buf.WriteByte(' ')
+			continue
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
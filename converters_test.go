@@ -18,6 +18,8 @@
 package ach
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -319,3 +321,50 @@ func TestConvertors_UTF8Truncation(t *testing.T) {
 	require.Equal(t, "Testée Samples0", c.alphaField("Testée Samples01", 15))
 	require.Equal(t, "Testée Samples0", c.stringField("Testée Samples01", 15))
 }
+
+func TestConverters__SanitizeName(t *testing.T) {
+	c := converters{}
+	require.Equal(t, "John & Co", c.sanitizeName("Jöhn & Co"))
+	require.Equal(t, "  Francois   Muller ", c.sanitizeName("  François   Müller "))
+	require.Equal(t, "Receiver Account Name", c.sanitizeName("Receiver Account Name"))
+	require.Equal(t, "Zoe   Co", c.sanitizeName("Zoë ☺ Co"))
+	require.Equal(t, "Tab Name", c.sanitizeName("Tab\tName"))
+
+	// punctuation Nacha allows is kept
+	require.Equal(t, "O'Brien-Smith, Inc.", c.sanitizeName("O'Brien-Smith, Inc."))
+	require.Equal(t, "Best Co. #23 / A&B", c.sanitizeName("Best Co. #23 / A&B"))
+}
+
+func TestConverters__WriteFields(t *testing.T) {
+	c := converters{}
+	var buf bytes.Buffer
+
+	for _, s := range []string{"", "a", "12345", "1234567890", "12345678901", "Gößmann", "Gößmann café"} {
+		for _, max := range []uint{0, 1, 5, 10, 94, 95} {
+			buf.Reset()
+			c.writeAlphaField(&buf, s, max)
+			require.Equal(t, c.alphaField(s, max), buf.String(), "alpha %q %d", s, max)
+
+			buf.Reset()
+			c.writeStringField(&buf, s, max)
+			require.Equal(t, c.stringField(s, max), buf.String(), "string %q %d", s, max)
+		}
+	}
+	for _, n := range []int{0, 7, -7, 1234567890, 12345678901} {
+		for _, max := range []uint{0, 1, 5, 10, 94, 95} {
+			buf.Reset()
+			c.writeNumericField(&buf, n, max)
+			require.Equal(t, c.numericField(n, max), buf.String(), "numeric %d %d", n, max)
+		}
+	}
+}
+
+func TestConverters__NumericFieldPadding(t *testing.T) {
+	c := converters{}
+	require.Equal(t, "", c.numericField(0, 0))
+	require.Equal(t, "00000", c.numericField(0, 5))
+	require.Equal(t, "000-7", c.numericField(-7, 5))
+	require.Equal(t, "45678", c.numericField(12345678, 5))
+	require.Equal(t, strings.Repeat("0", 93)+"1", c.numericField(1, 94))
+	require.Equal(t, "", c.numericField(1, 95))
+}
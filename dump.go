@@ -0,0 +1,256 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Dump writes a human readable, indented description of the File to w. Records are
+// labeled by name, transaction codes are decoded and amounts are formatted as dollars.
+//
+// Dump is meant for troubleshooting, use Writer to produce a Nacha formatted file.
+func (f *File) Dump(w io.Writer) {
+	if f == nil || w == nil {
+		return
+	}
+	fh := f.Header
+	fmt.Fprintln(w, "FileHeader")
+	fmt.Fprintf(w, "  ImmediateDestination: %s %s\n", strings.TrimSpace(fh.ImmediateDestinationField()), strings.TrimSpace(fh.ImmediateDestinationName))
+	fmt.Fprintf(w, "  ImmediateOrigin: %s %s\n", strings.TrimSpace(fh.ImmediateOriginField()), strings.TrimSpace(fh.ImmediateOriginName))
+	fmt.Fprintf(w, "  FileCreation: %s %s\n", fh.FileCreationDateField(), fh.FileCreationTimeField())
+	fmt.Fprintf(w, "  FileIDModifier: %s\n", fh.FileIDModifier)
+
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		dumpBatchHeader(w, bh)
+		for _, entry := range batch.GetEntries() {
+			dumpEntryDetail(w, entry)
+		}
+		for _, entry := range batch.GetADVEntries() {
+			fmt.Fprintf(w, "    ADVEntryDetail %s\n", entry.SequenceNumberField())
+			fmt.Fprintf(w, "      TransactionCode: %d %s\n", entry.TransactionCode, transactionCodeDescription(entry.TransactionCode))
+			fmt.Fprintf(w, "      RDFIIdentification: %s\n", entry.RDFIIdentificationField())
+			fmt.Fprintf(w, "      DFIAccountNumber: %s\n", strings.TrimSpace(entry.DFIAccountNumber))
+			fmt.Fprintf(w, "      Amount: %s\n", formatDollars(entry.Amount, true))
+			fmt.Fprintf(w, "      IndividualName: %s\n", strings.TrimSpace(entry.IndividualName))
+			if entry.Addenda99 != nil {
+				dumpAddenda99(w, entry.Addenda99)
+			}
+		}
+		if bh.StandardEntryClassCode != ADV {
+			if bc := batch.GetControl(); bc != nil {
+				dumpBatchControl(w, bc.EntryAddendaCount, bc.EntryHash, bc.TotalDebitEntryDollarAmount, bc.TotalCreditEntryDollarAmount)
+			}
+		} else if bc := batch.GetADVControl(); bc != nil {
+			dumpBatchControl(w, bc.EntryAddendaCount, bc.EntryHash, bc.TotalDebitEntryDollarAmount, bc.TotalCreditEntryDollarAmount)
+		}
+	}
+
+	for i := range f.IATBatches {
+		batch := &f.IATBatches[i]
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		fmt.Fprintf(w, "  Batch %d %s\n", bh.BatchNumber, bh.StandardEntryClassCode)
+		fmt.Fprintf(w, "    ServiceClassCode: %d\n", bh.ServiceClassCode)
+		fmt.Fprintf(w, "    OriginatorIdentification: %s\n", strings.TrimSpace(bh.OriginatorIdentification))
+		fmt.Fprintf(w, "    CompanyEntryDescription: %s\n", strings.TrimSpace(bh.CompanyEntryDescription))
+		fmt.Fprintf(w, "    Currency: %s to %s\n", bh.ISOOriginatingCurrencyCode, bh.ISODestinationCurrencyCode)
+		fmt.Fprintf(w, "    EffectiveEntryDate: %s\n", bh.EffectiveEntryDateField())
+		for _, entry := range batch.GetEntries() {
+			fmt.Fprintf(w, "    IATEntryDetail %s\n", entry.TraceNumberField())
+			fmt.Fprintf(w, "      TransactionCode: %d %s\n", entry.TransactionCode, transactionCodeDescription(entry.TransactionCode))
+			fmt.Fprintf(w, "      RDFIIdentification: %s\n", entry.RDFIIdentificationField())
+			fmt.Fprintf(w, "      DFIAccountNumber: %s\n", strings.TrimSpace(entry.DFIAccountNumber))
+			fmt.Fprintf(w, "      Amount: %s\n", formatDollars(entry.Amount, true))
+			if entry.Addenda10 != nil {
+				fmt.Fprintf(w, "      Addenda10: %s %s\n", entry.Addenda10.TransactionTypeCode, strings.TrimSpace(entry.Addenda10.Name))
+			}
+			for _, addenda17 := range entry.Addenda17 {
+				fmt.Fprintf(w, "      Addenda17 %d: %s\n", addenda17.SequenceNumber, strings.TrimSpace(addenda17.PaymentRelatedInformation))
+			}
+			if entry.Addenda98 != nil {
+				dumpAddenda98(w, entry.Addenda98)
+			}
+			if entry.Addenda99 != nil {
+				dumpAddenda99(w, entry.Addenda99)
+			}
+		}
+		if bc := batch.GetControl(); bc != nil {
+			dumpBatchControl(w, bc.EntryAddendaCount, bc.EntryHash, bc.TotalDebitEntryDollarAmount, bc.TotalCreditEntryDollarAmount)
+		}
+	}
+
+	fmt.Fprintln(w, "FileControl")
+	if f.IsADV() {
+		fc := f.ADVControl
+		fmt.Fprintf(w, "  BatchCount: %d\n", fc.BatchCount)
+		fmt.Fprintf(w, "  BlockCount: %d\n", fc.BlockCount)
+		fmt.Fprintf(w, "  EntryAddendaCount: %d\n", fc.EntryAddendaCount)
+		fmt.Fprintf(w, "  TotalDebits: %s\n", formatDollars(fc.TotalDebitEntryDollarAmountInFile, true))
+		fmt.Fprintf(w, "  TotalCredits: %s\n", formatDollars(fc.TotalCreditEntryDollarAmountInFile, true))
+		return
+	}
+	fc := f.Control
+	fmt.Fprintf(w, "  BatchCount: %d\n", fc.BatchCount)
+	fmt.Fprintf(w, "  BlockCount: %d\n", fc.BlockCount)
+	fmt.Fprintf(w, "  EntryAddendaCount: %d\n", fc.EntryAddendaCount)
+	fmt.Fprintf(w, "  EntryHash: %d\n", fc.EntryHash)
+	fmt.Fprintf(w, "  TotalDebits: %s\n", formatDollars(fc.TotalDebitEntryDollarAmountInFile, true))
+	fmt.Fprintf(w, "  TotalCredits: %s\n", formatDollars(fc.TotalCreditEntryDollarAmountInFile, true))
+}
+
+func dumpBatchHeader(w io.Writer, bh *BatchHeader) {
+	fmt.Fprintf(w, "  Batch %d %s\n", bh.BatchNumber, bh.StandardEntryClassCode)
+	fmt.Fprintf(w, "    ServiceClassCode: %d\n", bh.ServiceClassCode)
+	fmt.Fprintf(w, "    CompanyName: %s\n", strings.TrimSpace(bh.CompanyName))
+	fmt.Fprintf(w, "    CompanyIdentification: %s\n", strings.TrimSpace(bh.CompanyIdentification))
+	fmt.Fprintf(w, "    CompanyEntryDescription: %s\n", strings.TrimSpace(bh.CompanyEntryDescription))
+	fmt.Fprintf(w, "    EffectiveEntryDate: %s\n", bh.EffectiveEntryDateField())
+	fmt.Fprintf(w, "    ODFIIdentification: %s\n", bh.ODFIIdentificationField())
+}
+
+func dumpEntryDetail(w io.Writer, entry *EntryDetail) {
+	fmt.Fprintf(w, "    EntryDetail %s\n", entry.TraceNumberField())
+	fmt.Fprintf(w, "      TransactionCode: %d %s\n", entry.TransactionCode, transactionCodeDescription(entry.TransactionCode))
+	fmt.Fprintf(w, "      RDFIIdentification: %s%s\n", entry.RDFIIdentificationField(), entry.CheckDigit)
+	fmt.Fprintf(w, "      DFIAccountNumber: %s\n", strings.TrimSpace(entry.DFIAccountNumber))
+	fmt.Fprintf(w, "      Amount: %s\n", formatDollars(entry.Amount, true))
+	fmt.Fprintf(w, "      IdentificationNumber: %s\n", strings.TrimSpace(entry.IdentificationNumber))
+	fmt.Fprintf(w, "      IndividualName: %s\n", strings.TrimSpace(entry.IndividualName))
+	if entry.Category != "" {
+		fmt.Fprintf(w, "      Category: %s\n", entry.Category)
+	}
+	if a := entry.Addenda02; a != nil {
+		fmt.Fprintf(w, "      Addenda02: %s %s %s\n", strings.TrimSpace(a.TerminalIdentificationCode), strings.TrimSpace(a.TerminalLocation), strings.TrimSpace(a.TerminalCity))
+	}
+	for _, a := range entry.Addenda05 {
+		fmt.Fprintf(w, "      Addenda05 %d: %s\n", a.SequenceNumber, strings.TrimSpace(a.PaymentRelatedInformation))
+	}
+	if entry.Addenda98 != nil {
+		dumpAddenda98(w, entry.Addenda98)
+	}
+	if a := entry.Addenda98Refused; a != nil {
+		fmt.Fprintf(w, "      Addenda98Refused: %s %s\n", a.RefusedChangeCode, strings.TrimSpace(a.CorrectedData))
+	}
+	if entry.Addenda99 != nil {
+		dumpAddenda99(w, entry.Addenda99)
+	}
+	if a := entry.Addenda99Dishonored; a != nil {
+		fmt.Fprintf(w, "      Addenda99Dishonored: %s %s\n", a.DishonoredReturnReasonCode, a.OriginalEntryTraceNumber)
+	}
+	if a := entry.Addenda99Contested; a != nil {
+		fmt.Fprintf(w, "      Addenda99Contested: %s %s\n", a.ContestedReturnCode, a.OriginalEntryTraceNumber)
+	}
+}
+
+func dumpAddenda98(w io.Writer, a *Addenda98) {
+	fmt.Fprintf(w, "      Addenda98: %s %s\n", a.ChangeCode, strings.TrimSpace(a.CorrectedData))
+}
+
+func dumpAddenda99(w io.Writer, a *Addenda99) {
+	fmt.Fprintf(w, "      Addenda99: %s %s %s\n", a.ReturnCode, a.OriginalTrace, strings.TrimSpace(a.AddendaInformation))
+}
+
+func dumpBatchControl(w io.Writer, entryAddendaCount, entryHash, debits, credits int) {
+	fmt.Fprintln(w, "    BatchControl")
+	fmt.Fprintf(w, "      EntryAddendaCount: %d\n", entryAddendaCount)
+	fmt.Fprintf(w, "      EntryHash: %d\n", entryHash)
+	fmt.Fprintf(w, "      TotalDebits: %s\n", formatDollars(debits, true))
+	fmt.Fprintf(w, "      TotalCredits: %s\n", formatDollars(credits, true))
+}
+
+// transactionCodeDescriptions describe the account type and purpose of each TransactionCode
+var transactionCodeDescriptions = map[int]string{
+	CheckingReturnNOCCredit:            "Checking Return or NOC Credit",
+	CheckingCredit:                     "Checking Credit",
+	CheckingPrenoteCredit:              "Checking Prenote Credit",
+	CheckingZeroDollarRemittanceCredit: "Checking Zero Dollar Credit",
+	CheckingReturnNOCDebit:             "Checking Return or NOC Debit",
+	CheckingDebit:                      "Checking Debit",
+	CheckingPrenoteDebit:               "Checking Prenote Debit",
+	CheckingZeroDollarRemittanceDebit:  "Checking Zero Dollar Debit",
+
+	SavingsReturnNOCCredit:            "Savings Return or NOC Credit",
+	SavingsCredit:                     "Savings Credit",
+	SavingsPrenoteCredit:              "Savings Prenote Credit",
+	SavingsZeroDollarRemittanceCredit: "Savings Zero Dollar Credit",
+	SavingsReturnNOCDebit:             "Savings Return or NOC Debit",
+	SavingsDebit:                      "Savings Debit",
+	SavingsPrenoteDebit:               "Savings Prenote Debit",
+	SavingsZeroDollarRemittanceDebit:  "Savings Zero Dollar Debit",
+
+	GLReturnNOCCredit:            "GL Return or NOC Credit",
+	GLCredit:                     "GL Credit",
+	GLPrenoteCredit:              "GL Prenote Credit",
+	GLZeroDollarRemittanceCredit: "GL Zero Dollar Credit",
+	GLReturnNOCDebit:             "GL Return or NOC Debit",
+	GLDebit:                      "GL Debit",
+	GLPrenoteDebit:               "GL Prenote Debit",
+	GLZeroDollarRemittanceDebit:  "GL Zero Dollar Debit",
+
+	LoanReturnNOCCredit:            "Loan Return or NOC Credit",
+	LoanCredit:                     "Loan Credit",
+	LoanPrenoteCredit:              "Loan Prenote Credit",
+	LoanZeroDollarRemittanceCredit: "Loan Zero Dollar Credit",
+	LoanDebit:                      "Loan Debit",
+	LoanReturnNOCDebit:             "Loan Return or NOC Debit",
+
+	CreditForDebitsOriginated:     "Automated Accounting Advice",
+	DebitForCreditsOriginated:     "Automated Accounting Advice",
+	CreditForCreditsReceived:      "Automated Accounting Advice",
+	DebitForDebitsReceived:        "Automated Accounting Advice",
+	CreditForCreditsRejected:      "Automated Accounting Advice",
+	DebitForDebitsRejectedBatches: "Automated Accounting Advice",
+	CreditSummary:                 "Automated Accounting Advice",
+	DebitSummary:                  "Automated Accounting Advice",
+}
+
+// transactionCodeDescription describes the account type and purpose of a TransactionCode
+func transactionCodeDescription(code int) string {
+	if desc, exists := transactionCodeDescriptions[code]; exists {
+		return desc
+	}
+	return "Unknown"
+}
+
+// formatDollars renders an amount in cents as dollars. Display amounts are prefixed with $ and have
+// their dollars grouped with commas (e.g. 123456 as $1,234.56), otherwise a plain decimal is returned (1234.56).
+func formatDollars(amount int, display bool) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	dollars := strconv.Itoa(amount / 100)
+	if display {
+		for i := len(dollars) - 3; i > 0; i -= 3 {
+			dollars = dollars[:i] + "," + dollars[i:]
+		}
+		sign += "$"
+	}
+	return fmt.Sprintf("%s%s.%02d", sign, dollars, amount%100)
+}
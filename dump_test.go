@@ -0,0 +1,89 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__Dump(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	file.Dump(&buf)
+	out := buf.String()
+
+	require.True(t, strings.HasPrefix(out, "FileHeader\n"))
+	require.Contains(t, out, "  Batch 1 PPD\n")
+	require.Contains(t, out, "      TransactionCode: 27 Checking Debit\n")
+	require.Contains(t, out, "      Amount: $1,000,000.00\n")
+	require.Contains(t, out, "    BatchControl\n")
+	require.Contains(t, out, "FileControl\n")
+
+	// nil files and writers are ignored
+	var nilFile *File
+	nilFile.Dump(&buf)
+	file.Dump(nil)
+}
+
+func TestFile__DumpAddenda(t *testing.T) {
+	for _, name := range []string{"cor-example.ach", "return-WEB.ach", "20180713-IAT.ach", "adv-read.ach"} {
+		path := filepath.Join("test", "testdata", name)
+		if name == "adv-read.ach" {
+			path = filepath.Join("test", "ach-adv-read", name)
+		}
+		file, err := ReadFile(path)
+		require.NoError(t, err, name)
+
+		var buf bytes.Buffer
+		file.Dump(&buf)
+		require.Contains(t, buf.String(), "FileControl\n", name)
+	}
+}
+
+func TestFormatDollars(t *testing.T) {
+	require.Equal(t, "$0.00", formatDollars(0, true))
+	require.Equal(t, "$0.05", formatDollars(5, true))
+	require.Equal(t, "$123.45", formatDollars(12345, true))
+	require.Equal(t, "$1,234.56", formatDollars(123456, true))
+	require.Equal(t, "-$12,345,678.90", formatDollars(-1234567890, true))
+
+	require.Equal(t, "1234567.89", formatDollars(123456789, false))
+	require.Equal(t, "-1.50", formatDollars(-150, false))
+}
+
+func TestTransactionCodeDescription(t *testing.T) {
+	require.Equal(t, "Checking Credit", transactionCodeDescription(CheckingCredit))
+	require.Equal(t, "Savings Prenote Debit", transactionCodeDescription(SavingsPrenoteDebit))
+	require.Equal(t, "GL Return or NOC Credit", transactionCodeDescription(GLReturnNOCCredit))
+	require.Equal(t, "Loan Zero Dollar Credit", transactionCodeDescription(LoanZeroDollarRemittanceCredit))
+	require.Equal(t, "Loan Debit", transactionCodeDescription(LoanDebit))
+	require.Equal(t, "Unknown", transactionCodeDescription(25))
+	require.Equal(t, "Automated Accounting Advice", transactionCodeDescription(CreditForDebitsOriginated))
+	require.Equal(t, "Unknown", transactionCodeDescription(20))
+	require.Equal(t, "Unknown", transactionCodeDescription(99))
+	for _, code := range []int{57, 58, 59, 80, 89} {
+		require.Equal(t, "Unknown", transactionCodeDescription(code), code)
+	}
+}
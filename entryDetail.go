@@ -19,6 +19,7 @@ package ach
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -285,17 +286,24 @@ func (ed *EntryDetail) String() string {
 	buf := getBuffer()
 	defer saveBuffer(buf)
 
+	// Fields are written directly into buf rather than through their *Field() helpers
+	// to avoid allocating a padded string per field.
+	var num [20]byte
 	buf.WriteString(entryDetailPos)
-	buf.WriteString(strconv.Itoa(ed.TransactionCode))
-	buf.WriteString(ed.RDFIIdentificationField())
+	buf.Write(strconv.AppendInt(num[:0], int64(ed.TransactionCode), 10))
+	ed.writeStringField(buf, ed.RDFIIdentification, 8)
 	buf.WriteString(ed.CheckDigit)
-	buf.WriteString(ed.DFIAccountNumberField())
-	buf.WriteString(ed.AmountField())
-	buf.WriteString(ed.IdentificationNumberField())
-	buf.WriteString(ed.IndividualNameField())
-	buf.WriteString(ed.DiscretionaryDataField())
-	buf.WriteString(strconv.Itoa(ed.AddendaRecordIndicator))
-	buf.WriteString(ed.TraceNumberField())
+	ed.writeAlphaField(buf, ed.DFIAccountNumber, 17)
+	ed.writeNumericField(buf, ed.Amount, 10)
+	ed.writeAlphaField(buf, ed.IdentificationNumber, 15)
+	if ed.validateOpts != nil && ed.validateOpts.SanitizeNames {
+		ed.writeAlphaField(buf, ed.sanitizeName(ed.IndividualName), 22)
+	} else {
+		ed.writeAlphaField(buf, ed.IndividualName, 22)
+	}
+	ed.writeAlphaField(buf, ed.DiscretionaryData, 2)
+	buf.Write(strconv.AppendInt(num[:0], int64(ed.AddendaRecordIndicator), 10))
+	ed.writeStringField(buf, ed.TraceNumber, 15)
 
 	return buf.String()
 }
@@ -307,40 +315,93 @@ func (ed *EntryDetail) SetValidation(opts *ValidateOpts) {
 		return
 	}
 	ed.validateOpts = opts
+	for i := range ed.Addenda05 {
+		ed.Addenda05[i].SetValidation(opts)
+	}
 }
 
 // Validate performs NACHA format rule checks on the record and returns an error if not Validated
 // The first error encountered is returned and stops that parsing.
 func (ed *EntryDetail) Validate() error {
+	if errs := ed.validateFields(); len(errs) > 0 {
+		return errs[0]
+	}
+	return ed.isAddendaRecordIndicator()
+}
+
+// ValidateAll performs the same checks as Validate on the record and each of its addenda
+// records, but returns every error found instead of only the first.
+func (ed *EntryDetail) ValidateAll() []error {
+	errs := ed.validateFields()
+	errs = appendError(errs, ed.isAddendaRecordIndicator())
+
+	if ed.Addenda02 != nil {
+		errs = appendError(errs, ed.Addenda02.Validate())
+	}
+	for _, addenda05 := range ed.Addenda05 {
+		errs = appendError(errs, addenda05.Validate())
+	}
+	if ed.Addenda98 != nil {
+		errs = appendError(errs, ed.Addenda98.Validate())
+	}
+	if ed.Addenda98Refused != nil {
+		errs = appendError(errs, ed.Addenda98Refused.Validate())
+	}
+	if ed.Addenda99 != nil {
+		errs = appendError(errs, ed.Addenda99.Validate())
+	}
+	if ed.Addenda99Dishonored != nil {
+		errs = appendError(errs, ed.Addenda99Dishonored.Validate())
+	}
+	if ed.Addenda99Contested != nil {
+		errs = appendError(errs, ed.Addenda99Contested.Validate())
+	}
+	return errs
+}
+
+// validateFields checks each field of the record and returns the errors in the order they're found.
+func (ed *EntryDetail) validateFields() []error {
+	var errs []error
+
 	if err := ed.fieldInclusion(); err != nil {
-		return err
+		errs = append(errs, err)
 	}
 	if ed.validateOpts != nil && ed.validateOpts.CheckTransactionCode != nil {
 		if err := ed.validateOpts.CheckTransactionCode(ed.TransactionCode); err != nil {
-			return fieldError("TransactionCode", err, strconv.Itoa(ed.TransactionCode))
+			errs = append(errs, fieldError("TransactionCode", err, strconv.Itoa(ed.TransactionCode)))
 		}
 	} else {
 		if err := ed.isTransactionCode(ed.TransactionCode); err != nil {
-			return fieldError("TransactionCode", err, strconv.Itoa(ed.TransactionCode))
+			errs = append(errs, fieldError("TransactionCode", err, strconv.Itoa(ed.TransactionCode)))
 		}
 	}
 	if err := ed.isAlphanumeric(ed.DFIAccountNumber); err != nil {
-		return fieldError("DFIAccountNumber", err, ed.DFIAccountNumber)
+		errs = append(errs, fieldError("DFIAccountNumber", err, ed.DFIAccountNumber))
 	}
 	if ed.Amount < 0 {
-		return fieldError("Amount", ErrNegativeAmount, ed.Amount)
+		errs = append(errs, fieldError("Amount", ErrNegativeAmount, ed.Amount))
 	}
 	if err := ed.amountOverflowsField(); err != nil {
-		return fieldError("Amount", err, ed.Amount)
+		errs = append(errs, fieldError("Amount", err, ed.Amount))
+	}
+	if err := ed.prenoteAmount(); err != nil {
+		errs = append(errs, err)
 	}
 	if err := ed.isAlphanumeric(ed.IdentificationNumber); err != nil {
-		return fieldError("IdentificationNumber", err, ed.IdentificationNumber)
+		errs = append(errs, fieldError("IdentificationNumber", err, ed.IdentificationNumber))
+	}
+	individualName := ed.IndividualName
+	if ed.validateOpts != nil && ed.validateOpts.SanitizeNames {
+		individualName = ed.sanitizeName(individualName)
 	}
-	if err := ed.isAlphanumeric(ed.IndividualName); err != nil {
-		return fieldError("IndividualName", err, ed.IndividualName)
+	if err := ed.isAlphanumeric(individualName); err != nil {
+		errs = append(errs, fieldError("IndividualName", err, ed.IndividualName))
 	}
 	if err := ed.isAlphanumeric(ed.DiscretionaryData); err != nil {
-		return fieldError("DiscretionaryData", err, ed.DiscretionaryData)
+		errs = append(errs, fieldError("DiscretionaryData", err, ed.DiscretionaryData))
+	}
+	if ed.AddendaRecordIndicator != 0 && ed.AddendaRecordIndicator != 1 {
+		errs = append(errs, fieldError("AddendaRecordIndicator", ErrAddendaRecordIndicator, ed.AddendaRecordIndicator))
 	}
 
 	if ed.validateOpts == nil || !ed.validateOpts.AllowInvalidCheckDigit {
@@ -348,14 +409,27 @@ func (ed *EntryDetail) Validate() error {
 
 		edCheckDigit, err := strconv.Atoi(ed.CheckDigit)
 		if err != nil {
-			return fieldError("CheckDigit", err, ed.CheckDigit)
-		}
-
-		if calculated != edCheckDigit {
-			return fieldError("RDFIIdentification", NewErrValidCheckDigit(calculated), ed.CheckDigit)
+			errs = append(errs, fieldError("CheckDigit", err, ed.CheckDigit))
+		} else if calculated != edCheckDigit {
+			errs = append(errs, fieldError("RDFIIdentification", NewErrValidCheckDigit(calculated), ed.CheckDigit))
 		}
 	}
 
+	return errs
+}
+
+// prenoteAmount returns an error if a prenote transaction code carries a non-zero
+// Amount. Returned prenotes are left to the batch level checks.
+func (ed *EntryDetail) prenoteAmount() error {
+	if ed.validateOpts != nil && ed.validateOpts.AllowInvalidAmounts {
+		return nil
+	}
+	if ed.Addenda99 != nil || ed.Addenda99Contested != nil || ed.Addenda99Dishonored != nil {
+		return nil
+	}
+	if ed.isPrenote(ed.TransactionCode) && ed.Amount != 0 {
+		return fieldError("Amount", ErrBatchAmountNonZero, ed.Amount)
+	}
 	return nil
 }
 
@@ -368,7 +442,7 @@ func (ed *EntryDetail) fieldInclusion() error {
 	if ed.RDFIIdentification == "" {
 		return fieldError("RDFIIdentification", ErrConstructor, ed.RDFIIdentificationField())
 	}
-	if ed.DFIAccountNumber == "" {
+	if strings.TrimSpace(ed.DFIAccountNumber) == "" {
 		return fieldError("DFIAccountNumber", ErrConstructor, ed.DFIAccountNumber)
 	}
 	if ed.IndividualName == "" {
@@ -400,6 +474,18 @@ func (ed *EntryDetail) SetRDFI(rdfi string) *EntryDetail {
 	return ed
 }
 
+// FixCheckDigit recalculates CheckDigit from RDFIIdentification and overwrites it, correcting
+// entries whose routing number prefix is right but the ninth digit is wrong or missing.
+// CheckDigit is left unchanged when RDFIIdentification is not 8 digits.
+func (ed *EntryDetail) FixCheckDigit() {
+	if len(ed.RDFIIdentification) != 8 {
+		return
+	}
+	if check := CalculateCheckDigit(ed.RDFIIdentification); check >= 0 {
+		ed.CheckDigit = strconv.Itoa(check)
+	}
+}
+
 // SetTraceNumber takes first 8 digits of ODFI and concatenates a sequence number onto the TraceNumber
 func (ed *EntryDetail) SetTraceNumber(ODFIIdentification string, seq int) {
 	traceNumber := ed.stringField(ODFIIdentification, 8) + ed.numericField(seq, 7)
@@ -441,18 +527,36 @@ func (ed *EntryDetail) AmountField() string {
 	return ed.numericField(ed.Amount, 10)
 }
 
+// AmountDollars returns Amount converted from cents to dollars
+func (ed *EntryDetail) AmountDollars() float64 {
+	return float64(ed.Amount) / 100
+}
+
+// SetAmountDollars sets Amount in cents from a dollar value, rounding half-up to the nearest cent.
+func (ed *EntryDetail) SetAmountDollars(d float64) {
+	// Round away float error (e.g. 1.005*100 = 100.49999...) before rounding to whole cents
+	cents := math.Round(d*100*1e6) / 1e6
+	ed.Amount = int(math.Floor(cents + 0.5))
+}
+
 // IdentificationNumberField returns a space padded string of IdentificationNumber
 func (ed *EntryDetail) IdentificationNumberField() string {
 	return ed.alphaField(ed.IdentificationNumber, 15)
 }
 
-// CheckSerialNumberField is used in RCK, ARC, BOC files but returns
+// CheckSerialNumberField is used in RCK, ARC, BOC, XCK files but returns
 // a space padded string of the underlying IdentificationNumber field
 func (ed *EntryDetail) CheckSerialNumberField() string {
 	return ed.alphaField(ed.IdentificationNumber, 15)
 }
 
-// SetCheckSerialNumber setter for RCK, ARC, BOC CheckSerialNumber
+// CheckSerialNumber returns the RCK, ARC, BOC, XCK CheckSerialNumber without padding,
+// which is the underlying IdentificationNumber. POP entries use POPCheckSerialNumberField.
+func (ed *EntryDetail) CheckSerialNumber() string {
+	return strings.TrimSpace(ed.IdentificationNumber)
+}
+
+// SetCheckSerialNumber setter for RCK, ARC, BOC, XCK CheckSerialNumber
 // which is underlying IdentificationNumber
 func (ed *EntryDetail) SetCheckSerialNumber(s string) {
 	ed.IdentificationNumber = s
@@ -479,19 +583,19 @@ func (ed *EntryDetail) SetPOPTerminalState(s string) {
 // POPCheckSerialNumberField is used in POP, characters 1-9 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPCheckSerialNumberField() string {
-	return ed.parseStringField(ed.IdentificationNumber[0:9])
+	return ed.parseStringField(ed.IdentificationNumberField()[0:9])
 }
 
 // POPTerminalCityField is used in POP, characters 10-13 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPTerminalCityField() string {
-	return ed.parseStringField(ed.IdentificationNumber[9:13])
+	return ed.parseStringField(ed.IdentificationNumberField()[9:13])
 }
 
 // POPTerminalStateField is used in POP, characters 14-15 of underlying BatchPOP
 // CheckSerialNumber / IdentificationNumber
 func (ed *EntryDetail) POPTerminalStateField() string {
-	return ed.parseStringField(ed.IdentificationNumber[13:15])
+	return ed.parseStringField(ed.IdentificationNumberField()[13:15])
 }
 
 // SetSHRCardExpirationDate format MMYY is used in SHR, characters 1-4 of underlying
@@ -515,13 +619,13 @@ func (ed *EntryDetail) SetSHRIndividualCardAccountNumber(s string) {
 // SHRCardExpirationDateField format MMYY is used in SHR, characters 1-4 of underlying
 // IdentificationNumber
 func (ed *EntryDetail) SHRCardExpirationDateField() string {
-	return ed.alphaField(ed.parseStringField(ed.IdentificationNumber[0:4]), 4)
+	return ed.alphaField(ed.parseStringField(ed.IdentificationNumberField()[0:4]), 4)
 }
 
 // SHRDocumentReferenceNumberField format int is used in SHR, characters 5-15 of underlying
 // IdentificationNumber
 func (ed *EntryDetail) SHRDocumentReferenceNumberField() string {
-	return ed.stringField(ed.IdentificationNumber[4:15], 11)
+	return ed.stringField(ed.IdentificationNumberField()[4:15], 11)
 }
 
 // SHRIndividualCardAccountNumberField format int is used in SHR, underlying
@@ -557,7 +661,10 @@ func (ed *EntryDetail) SetOriginalTraceNumber(s string) {
 
 // SetCATXAddendaRecords setter for CTX and ATX AddendaRecords characters 1-4 of underlying IndividualName
 func (ed *EntryDetail) SetCATXAddendaRecords(i int) {
-	ed.AddendaRecordIndicator = i
+	ed.AddendaRecordIndicator = 0
+	if i > 0 {
+		ed.AddendaRecordIndicator = 1
+	}
 
 	count := ed.numericField(i, 4)
 	current := ed.IndividualName
@@ -598,7 +705,7 @@ func (ed *EntryDetail) CATXReceivingCompanyField() string {
 
 // CATXReservedField is used in CTX and ATX files, characters 21-22 of underlying IndividualName field
 func (ed *EntryDetail) CATXReservedField() string {
-	return ed.IndividualName[20:22]
+	return ed.IndividualNameField()[20:22]
 }
 
 // DiscretionaryDataField returns a space padded string of DiscretionaryData
@@ -624,32 +731,45 @@ func (ed *EntryDetail) SetPaymentType(t string) {
 	}
 }
 
-// SetProcessControlField setter for TRC Process Control Field characters 1-6 of underlying IndividualName
+// CardTransactionTypeField returns the DiscretionaryData field used in POS and SHR batch files
+func (ed *EntryDetail) CardTransactionTypeField() string {
+	return ed.DiscretionaryDataField()
+}
+
+// SetCardTransactionType sets the Card Transaction Type Code (e.g. "01" for a purchase of goods or services).
+// This is used for POS and SHR batch files in-place of DiscretionaryData.
+func (ed *EntryDetail) SetCardTransactionType(code string) {
+	ed.DiscretionaryData = ed.alphaField(strings.TrimSpace(code), 2)
+}
+
+// SetProcessControlField setter for TRC and XCK Process Control Field characters 1-6 of underlying IndividualName.
+// An Item Research Number already set in characters 7-22 is kept.
 func (ed *EntryDetail) SetProcessControlField(s string) {
-	ed.IndividualName = ed.alphaField(s, 6)
+	ed.IndividualName = ed.alphaField(s, 6) + ed.IndividualNameField()[6:22]
 }
 
-// SetItemResearchNumber setter for TRC Item Research Number characters 7-22 of underlying IndividualName
+// SetItemResearchNumber setter for TRC and XCK Item Research Number characters 7-22 of underlying IndividualName.
+// A Process Control Field already set in characters 1-6 is kept.
 func (ed *EntryDetail) SetItemResearchNumber(s string) {
-	ed.IndividualName = ed.IndividualName + ed.alphaField(s, 16)
+	ed.IndividualName = ed.IndividualNameField()[0:6] + ed.alphaField(s, 16)
 }
 
-// SetItemTypeIndicator setter for TRC Item Type Indicator which is underlying Discretionary Data
+// SetItemTypeIndicator setter for TRC and TRX Item Type Indicator which is underlying Discretionary Data
 func (ed *EntryDetail) SetItemTypeIndicator(s string) {
 	ed.DiscretionaryData = ed.alphaField(s, 2)
 }
 
-// ProcessControlField getter for TRC Process Control Field characters 1-6 of underlying IndividualName
+// ProcessControlField getter for TRC and XCK Process Control Field characters 1-6 of underlying IndividualName
 func (ed *EntryDetail) ProcessControlField() string {
-	return ed.parseStringField(ed.IndividualName[0:6])
+	return ed.parseStringField(ed.IndividualNameField()[0:6])
 }
 
-// ItemResearchNumber getter for TRC Item Research Number characters 7-22 of underlying IndividualName
+// ItemResearchNumber getter for TRC and XCK Item Research Number characters 7-22 of underlying IndividualName
 func (ed *EntryDetail) ItemResearchNumber() string {
-	return ed.parseStringField(ed.IndividualName[6:22])
+	return ed.parseStringField(ed.IndividualNameField()[6:22])
 }
 
-// ItemTypeIndicator getter for TRC Item Type Indicator which is underlying Discretionary Data
+// ItemTypeIndicator getter for TRC and TRX Item Type Indicator which is underlying Discretionary Data
 func (ed *EntryDetail) ItemTypeIndicator() string {
 	return ed.DiscretionaryData
 }
@@ -659,29 +779,227 @@ func (ed *EntryDetail) TraceNumberField() string {
 	return ed.stringField(ed.TraceNumber, 15)
 }
 
-// CreditOrDebit returns a "C" for credit or "D" for debit based on the entry TransactionCode
+// hasAddenda returns true when any addenda record is attached to the EntryDetail
+func (ed *EntryDetail) hasAddenda() bool {
+	return ed.Addenda02 != nil || len(ed.Addenda05) > 0 || ed.Addenda98 != nil || ed.Addenda98Refused != nil ||
+		ed.Addenda99 != nil || ed.Addenda99Dishonored != nil || ed.Addenda99Contested != nil
+}
+
+// isAddendaRecordIndicator checks an AddendaRecordIndicator of 0 or 1 matches whether the entry has addenda records.
+// Other values are reported by validateFields.
+func (ed *EntryDetail) isAddendaRecordIndicator() error {
+	if ed.AddendaRecordIndicator != 0 && ed.AddendaRecordIndicator != 1 {
+		return nil
+	}
+	hasAddenda := ed.hasAddenda()
+	if ed.AddendaRecordIndicator == 0 && hasAddenda {
+		return fieldError("AddendaRecordIndicator", ErrBatchAddendaIndicator, ed.AddendaRecordIndicator)
+	}
+	if ed.AddendaRecordIndicator == 1 && !hasAddenda {
+		return fieldError("AddendaRecordIndicator", ErrAddendaRecordIndicatorNoAddenda, ed.AddendaRecordIndicator)
+	}
+	return nil
+}
+
+// CreditOrDebit returns a "C" for credit or "D" for debit based on the entry TransactionCode.
+//
+// Returns, prenotes and zero dollar remittance codes are classified along with their
+// account type (e.g. 21, 23 and 24 are credits). An empty string is returned for codes
+// which are not NACHA transaction codes, unless ValidateOpts.CheckTransactionCode is set
+// in which case custom codes are classified by their second digit.
 func (ed *EntryDetail) CreditOrDebit() string {
 	if ed.TransactionCode < 10 || ed.TransactionCode > 99 {
 		return ""
 	}
-	tc := strconv.Itoa(ed.TransactionCode)
+	if ed.validateOpts == nil || ed.validateOpts.CheckTransactionCode == nil {
+		if err := StandardTransactionCode(ed.TransactionCode); err != nil {
+			return ""
+		}
+	}
+
+	// Accounting records (ADV) alternate between credit and debit
+	if ed.TransactionCode >= CreditForDebitsOriginated && ed.TransactionCode <= DebitSummary {
+		if ed.TransactionCode%2 == 1 {
+			return "C"
+		}
+		return "D"
+	}
 
 	// take the second number in the TransactionCode
-	switch tc[1:2] {
-	case "1", "2", "3", "4":
+	switch ed.TransactionCode % 10 {
+	case 1, 2, 3, 4:
 		return "C"
-	case "5", "6", "7", "8", "9":
+	case 5, 6, 7, 8, 9:
 		return "D"
-	default:
 	}
 	return ""
 }
 
+// IsCredit returns true when the TransactionCode credits the receiver's account, see CreditOrDebit.
+func (ed *EntryDetail) IsCredit() bool {
+	return ed.CreditOrDebit() == "C"
+}
+
+// IsDebit returns true when the TransactionCode debits the receiver's account, see CreditOrDebit.
+func (ed *EntryDetail) IsDebit() bool {
+	return ed.CreditOrDebit() == "D"
+}
+
+// IsPrenote returns true when the TransactionCode is a prenotification (e.g. 23 or 28)
+func (ed *EntryDetail) IsPrenote() bool {
+	return ed.isPrenote(ed.TransactionCode)
+}
+
+// IsReturn returns true when the EntryDetail is a return, either by its Category or by
+// carrying an Addenda99, Addenda99Dishonored or Addenda99Contested record.
+func (ed *EntryDetail) IsReturn() bool {
+	return ed.Category == CategoryReturn ||
+		ed.Addenda99 != nil || ed.Addenda99Dishonored != nil || ed.Addenda99Contested != nil
+}
+
+// IsNOC returns true when the EntryDetail is a Notification of Change, either by its Category
+// or by carrying an Addenda98 or Addenda98Refused record.
+func (ed *EntryDetail) IsNOC() bool {
+	return ed.Category == CategoryNOC || ed.Addenda98 != nil || ed.Addenda98Refused != nil
+}
+
+// Clone returns a deep copy of the EntryDetail. Each addenda record is copied so changes
+// to the clone do not affect the original. ValidateOpts are shared between both.
+func (ed *EntryDetail) Clone() *EntryDetail {
+	if ed == nil {
+		return nil
+	}
+	out := *ed
+	if ed.Addenda02 != nil {
+		addenda02 := *ed.Addenda02
+		out.Addenda02 = &addenda02
+	}
+	if ed.Addenda05 != nil {
+		out.Addenda05 = make([]*Addenda05, len(ed.Addenda05))
+		for i := range ed.Addenda05 {
+			if ed.Addenda05[i] != nil {
+				addenda05 := *ed.Addenda05[i]
+				out.Addenda05[i] = &addenda05
+			}
+		}
+	}
+	if ed.Addenda98 != nil {
+		addenda98 := *ed.Addenda98
+		out.Addenda98 = &addenda98
+	}
+	if ed.Addenda98Refused != nil {
+		addenda98Refused := *ed.Addenda98Refused
+		out.Addenda98Refused = &addenda98Refused
+	}
+	if ed.Addenda99 != nil {
+		addenda99 := *ed.Addenda99
+		out.Addenda99 = &addenda99
+	}
+	if ed.Addenda99Contested != nil {
+		addenda99Contested := *ed.Addenda99Contested
+		out.Addenda99Contested = &addenda99Contested
+	}
+	if ed.Addenda99Dishonored != nil {
+		addenda99Dishonored := *ed.Addenda99Dishonored
+		out.Addenda99Dishonored = &addenda99Dishonored
+	}
+	return &out
+}
+
 // AddAddenda05 appends an Addenda05 to the EntryDetail
 func (ed *EntryDetail) AddAddenda05(addenda05 *Addenda05) {
 	ed.Addenda05 = append(ed.Addenda05, addenda05)
 }
 
+// Addendumer is implemented by each addenda record which can be attached to an EntryDetail
+type Addendumer interface {
+	Parse(record string)
+	String() string
+	Validate() error
+}
+
+// EachAddenda calls fn with each addenda record on the EntryDetail in the order they're written.
+// Records are passed as their concrete pointer types, so fn may modify them in place. The first
+// error returned by fn stops iteration and is returned.
+func (ed *EntryDetail) EachAddenda(fn func(Addendumer) error) error {
+	var addenda []Addendumer
+	if ed.Addenda02 != nil {
+		addenda = append(addenda, ed.Addenda02)
+	}
+	for i := range ed.Addenda05 {
+		if ed.Addenda05[i] != nil {
+			addenda = append(addenda, ed.Addenda05[i])
+		}
+	}
+	if ed.Addenda98 != nil {
+		addenda = append(addenda, ed.Addenda98)
+	}
+	if ed.Addenda98Refused != nil {
+		addenda = append(addenda, ed.Addenda98Refused)
+	}
+	if ed.Addenda99 != nil {
+		addenda = append(addenda, ed.Addenda99)
+	}
+	if ed.Addenda99Dishonored != nil {
+		addenda = append(addenda, ed.Addenda99Dishonored)
+	}
+	if ed.Addenda99Contested != nil {
+		addenda = append(addenda, ed.Addenda99Contested)
+	}
+	for i := range addenda {
+		if err := fn(addenda[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceAddenda swaps the addenda record old, as passed by EachAddenda, for replacement of the same type.
+func (ed *EntryDetail) ReplaceAddenda(old, replacement Addendumer) error {
+	switch o := old.(type) {
+	case *Addenda02:
+		if r, ok := replacement.(*Addenda02); ok && ed.Addenda02 == o {
+			ed.Addenda02 = r
+			return nil
+		}
+	case *Addenda05:
+		if r, ok := replacement.(*Addenda05); ok {
+			for i := range ed.Addenda05 {
+				if ed.Addenda05[i] == o {
+					ed.Addenda05[i] = r
+					return nil
+				}
+			}
+		}
+	case *Addenda98:
+		if r, ok := replacement.(*Addenda98); ok && ed.Addenda98 == o {
+			ed.Addenda98 = r
+			return nil
+		}
+	case *Addenda98Refused:
+		if r, ok := replacement.(*Addenda98Refused); ok && ed.Addenda98Refused == o {
+			ed.Addenda98Refused = r
+			return nil
+		}
+	case *Addenda99:
+		if r, ok := replacement.(*Addenda99); ok && ed.Addenda99 == o {
+			ed.Addenda99 = r
+			return nil
+		}
+	case *Addenda99Dishonored:
+		if r, ok := replacement.(*Addenda99Dishonored); ok && ed.Addenda99Dishonored == o {
+			ed.Addenda99Dishonored = r
+			return nil
+		}
+	case *Addenda99Contested:
+		if r, ok := replacement.(*Addenda99Contested); ok && ed.Addenda99Contested == o {
+			ed.Addenda99Contested = r
+			return nil
+		}
+	}
+	return fmt.Errorf("%T addenda not found on EntryDetail or replaced with %T", old, replacement)
+}
+
 // addendaCount returns the count of Addenda records added onto this EntryDetail
 func (ed *EntryDetail) addendaCount() (n int) {
 	if ed.Addenda02 != nil {
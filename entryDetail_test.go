@@ -20,6 +20,7 @@ package ach
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -549,15 +550,48 @@ func testEDCreditOrDebit(t testing.TB) {
 		00:  "", // invalid
 		1:   "",
 		108: "",
+		12:  "",
+		25:  "",
+		45:  "",
+		57:  "",
+		62:  "",
+		89:  "",
 		// valid
+		21: "C",
 		22: "C",
 		23: "C",
+		24: "C",
+		26: "D",
 		27: "D",
 		28: "D",
+		29: "D",
+		31: "C",
 		32: "C",
 		33: "C",
+		34: "C",
+		36: "D",
 		37: "D",
 		38: "D",
+		39: "D",
+		41: "C",
+		42: "C",
+		43: "C",
+		44: "C",
+		46: "D",
+		47: "D",
+		48: "D",
+		49: "D",
+		51: "C",
+		52: "C",
+		53: "C",
+		54: "C",
+		55: "D",
+		56: "D",
+		// ADV
+		81: "C",
+		82: "D",
+		87: "C",
+		88: "D",
 	}
 	for code, expected := range cases {
 		entry.TransactionCode = code
@@ -565,6 +599,15 @@ func testEDCreditOrDebit(t testing.TB) {
 			t.Errorf("TransactionCode %d expected %s, got %s", code, expected, v)
 		}
 	}
+
+	// custom transaction codes are classified when allowed
+	entry.SetValidation(&ValidateOpts{
+		CheckTransactionCode: func(code int) error { return nil },
+	})
+	entry.TransactionCode = 12
+	if v := entry.CreditOrDebit(); v != "C" {
+		t.Errorf("TransactionCode %d expected C, got %s", entry.TransactionCode, v)
+	}
 }
 
 // TestEDCreditOrDebit tests validating debit and credit transaction code
@@ -778,3 +821,339 @@ func TestEntryDetail_UTF8Truncation(t *testing.T) {
 	require.Equal(t, "Testée Samples0", ed.IdentificationNumberField())
 	require.Equal(t, "Testée Samples01", ed.IdentificationNumber)
 }
+
+func TestEntryDetail_ValidateAll(t *testing.T) {
+	ed := mockEntryDetail()
+	require.Empty(t, ed.ValidateAll())
+
+	ed.Amount = -1
+	ed.IndividualName = "Jane ®"
+	ed.CheckDigit = "6"
+	ed.AddAddenda05(NewAddenda05()) // missing PaymentRelatedInformation is fine, but TypeCode is required
+	ed.Addenda05[0].TypeCode = ""
+	ed.AddendaRecordIndicator = 1
+
+	errs := ed.ValidateAll()
+	require.Len(t, errs, 4)
+	require.ErrorIs(t, errs[0], ErrNegativeAmount)
+	require.ErrorIs(t, errs[1], ErrNonAlphanumeric)
+
+	var checkDigit ErrValidCheckDigit
+	require.ErrorAs(t, errs[2], &checkDigit)
+	require.ErrorIs(t, errs[3], ErrConstructor)
+
+	// Validate returns the first error
+	require.Equal(t, errs[0].Error(), ed.Validate().Error())
+}
+
+func TestEntryDetail__ParseShortRecord(t *testing.T) {
+	line := "62705320001912345            0000010500c-1            Arnold Wade           DD0076401255655291"
+
+	ed := NewEntryDetail()
+	ed.Parse(line[:80])
+	require.Equal(t, 0, ed.TransactionCode)
+	require.Error(t, ed.Validate())
+
+	// accessors reading fixed positions must not panic on short values
+	require.Equal(t, "", ed.POPCheckSerialNumberField())
+	require.Equal(t, "", ed.POPTerminalCityField())
+	require.Equal(t, "", ed.POPTerminalStateField())
+	require.Equal(t, "  ", ed.CATXReservedField())
+	require.Equal(t, "", ed.ProcessControlField())
+	require.Equal(t, "", ed.ItemResearchNumber())
+
+	ed.IdentificationNumber = "12345"
+	ed.IndividualName = "ABC"
+	require.Equal(t, "12345", ed.POPCheckSerialNumberField())
+	require.Equal(t, "ABC", ed.ProcessControlField())
+}
+
+func TestEntryDetail__AmountDollars(t *testing.T) {
+	ed := mockEntryDetail()
+
+	cases := map[float64]int{
+		19.99:  1999,
+		0.10:   10,
+		0.29:   29,
+		1.005:  101,
+		1.004:  100,
+		100:    10000,
+		0.0:    0,
+		4.35:   435,
+		1234.5: 123450,
+	}
+	for dollars, cents := range cases {
+		ed.SetAmountDollars(dollars)
+		require.Equal(t, cents, ed.Amount, "%v", dollars)
+	}
+
+	ed.Amount = 1999
+	require.Equal(t, 19.99, ed.AmountDollars())
+	ed.Amount = 10
+	require.Equal(t, 0.10, ed.AmountDollars())
+}
+
+func TestEntryDetail__Clone(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.AddAddenda05(mockAddenda05())
+	ed.Addenda99 = mockAddenda99()
+
+	clone := ed.Clone()
+	require.Equal(t, ed.String(), clone.String())
+	require.Equal(t, ed.Addenda05[0].String(), clone.Addenda05[0].String())
+
+	clone.Amount = 1
+	clone.Addenda05[0].PaymentRelatedInformation = "changed"
+	clone.Addenda99.ReturnCode = "R02"
+	clone.AddAddenda05(mockAddenda05())
+
+	require.Equal(t, 100000000, ed.Amount)
+	require.Len(t, ed.Addenda05, 1)
+	require.NotEqual(t, "changed", ed.Addenda05[0].PaymentRelatedInformation)
+	require.Equal(t, "R07", ed.Addenda99.ReturnCode)
+
+	var nilEntry *EntryDetail
+	require.Nil(t, nilEntry.Clone())
+}
+
+func TestEntryDetail__BlankDFIAccountNumber(t *testing.T) {
+	line := "62705320001                  0000010500c-1            Arnold Wade           DD0076401255655291"
+
+	ed := NewEntryDetail()
+	ed.SetValidation(&ValidateOpts{PreserveSpaces: true})
+	ed.Parse(line)
+	require.Equal(t, strings.Repeat(" ", 17), ed.DFIAccountNumber)
+
+	err := ed.Validate()
+	require.ErrorIs(t, err, ErrConstructor)
+	require.Contains(t, err.Error(), "DFIAccountNumber")
+
+	entry := mockEntryDetail()
+	entry.DFIAccountNumber = "   "
+	require.ErrorIs(t, entry.Validate(), ErrConstructor)
+}
+
+func TestEntryDetail__SanitizeNames(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.IndividualName = "Jöhn & Co"
+	require.NoError(t, ed.Validate())
+	require.Contains(t, ed.String(), ed.alphaField("Jöhn & Co", 22))
+
+	ed.SetValidation(&ValidateOpts{SanitizeNames: true})
+	require.NoError(t, ed.Validate())
+	require.Equal(t, ed.alphaField("John & Co", 22), ed.String()[54:76])
+	require.Equal(t, "Jöhn & Co", ed.IndividualName)
+}
+
+func TestEntryDetail__SanitizeNamesPositional(t *testing.T) {
+	opts := &ValidateOpts{SanitizeNames: true}
+
+	// TRC stores the Process Control Field and Item Research Number in IndividualName
+	trc := mockTRCEntryDetail()
+	trc.SetProcessControlField("CHK")
+	trc.SetItemResearchNumber("12345")
+	trc.SetValidation(opts)
+
+	read := new(EntryDetail)
+	read.Parse(trc.String())
+	require.Equal(t, "CHK", read.ProcessControlField())
+	require.Equal(t, "12345", read.ItemResearchNumber())
+
+	// CTX stores the addenda count and receiving company name in IndividualName
+	ctx := mockCTXEntryDetail()
+	ctx.SetCATXAddendaRecords(3)
+	ctx.SetCATXReceivingCompany("Müller & Co")
+	ctx.SetValidation(opts)
+
+	read = new(EntryDetail)
+	read.Parse(ctx.String())
+	require.Equal(t, "0003", read.CATXAddendaRecordsField())
+	require.Equal(t, "Muller & Co", strings.TrimSpace(read.CATXReceivingCompanyField()))
+	require.Len(t, ctx.String(), RecordLength)
+}
+
+func BenchmarkEntryDetail__String(b *testing.B) {
+	ed := mockEntryDetail()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ed.String()
+	}
+}
+
+func TestEntryDetail__TransactionCodeConstants(t *testing.T) {
+	// These values are fixed by the Nacha rules and are written directly into files
+	require.Equal(t, 22, CheckingCredit)
+	require.Equal(t, 23, CheckingPrenoteCredit)
+	require.Equal(t, 27, CheckingDebit)
+	require.Equal(t, 28, CheckingPrenoteDebit)
+	require.Equal(t, 32, SavingsCredit)
+	require.Equal(t, 33, SavingsPrenoteCredit)
+	require.Equal(t, 37, SavingsDebit)
+	require.Equal(t, 38, SavingsPrenoteDebit)
+
+	ed := mockEntryDetail()
+	ed.TransactionCode = SavingsDebit
+	require.Equal(t, "637", ed.String()[:3])
+}
+
+func TestEntryDetail__AddendaRecordIndicator(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.AddendaRecordIndicator = 2
+	require.ErrorIs(t, ed.Validate(), ErrAddendaRecordIndicator)
+
+	// the indicator must match whether addenda records are present
+	ed.AddendaRecordIndicator = 1
+	err := ed.Validate()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecordIndicator", fe.FieldName)
+	require.ErrorIs(t, err, ErrAddendaRecordIndicatorNoAddenda)
+
+	ed.AddAddenda05(mockAddenda05())
+	require.NoError(t, ed.Validate())
+
+	ed.AddendaRecordIndicator = 0
+	err = ed.Validate()
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecordIndicator", fe.FieldName)
+	require.ErrorIs(t, err, ErrBatchAddendaIndicator)
+	require.Len(t, ed.ValidateAll(), 1)
+
+	// CTX/ATX addenda counts only flag that addenda exist
+	ed.SetCATXAddendaRecords(3)
+	require.Equal(t, 1, ed.AddendaRecordIndicator)
+	require.Equal(t, "0003", ed.CATXAddendaRecordsField())
+	ed.SetCATXAddendaRecords(0)
+	require.Equal(t, 0, ed.AddendaRecordIndicator)
+}
+
+func TestEntryDetail__Predicates(t *testing.T) {
+	ed := mockEntryDetail()
+	require.True(t, ed.IsCredit())
+	require.False(t, ed.IsDebit())
+	require.False(t, ed.IsPrenote())
+	require.False(t, ed.IsReturn())
+	require.False(t, ed.IsNOC())
+
+	ed.TransactionCode = CheckingPrenoteDebit
+	require.True(t, ed.IsDebit())
+	require.True(t, ed.IsPrenote())
+
+	ed.TransactionCode = 99
+	require.False(t, ed.IsCredit())
+	require.False(t, ed.IsDebit())
+
+	returns, err := ReadFile(filepath.Join("test", "testdata", "return-WEB.ach"))
+	require.NoError(t, err)
+	for _, entry := range returns.Batches[0].GetEntries() {
+		require.True(t, entry.IsReturn())
+		require.False(t, entry.IsNOC())
+	}
+
+	nocs, err := ReadFile(filepath.Join("test", "testdata", "cor-example.ach"))
+	require.NoError(t, err)
+	for _, entry := range nocs.Batches[0].GetEntries() {
+		require.True(t, entry.IsNOC())
+		require.False(t, entry.IsReturn())
+	}
+}
+
+func TestEntryDetail__FixCheckDigit(t *testing.T) {
+	ed := mockEntryDetail()
+	expected := ed.CheckDigit
+
+	for _, digit := range []string{"", "9", "X"} {
+		ed.CheckDigit = digit
+		require.Error(t, ed.Validate())
+		ed.FixCheckDigit()
+		require.Equal(t, expected, ed.CheckDigit)
+		require.NoError(t, ed.Validate())
+	}
+
+	// Invalid routing prefixes are left alone
+	ed.RDFIIdentification = "1234"
+	ed.CheckDigit = "9"
+	ed.FixCheckDigit()
+	require.Equal(t, "9", ed.CheckDigit)
+}
+
+func TestEntryDetail__PrenoteAmount(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.TransactionCode = CheckingPrenoteCredit
+	ed.Amount = 100
+
+	err := ed.Validate()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "Amount", fe.FieldName)
+	require.ErrorIs(t, err, ErrBatchAmountNonZero)
+
+	ed.Amount = 0
+	require.NoError(t, ed.Validate())
+
+	ed.Amount = 100
+	ed.SetValidation(&ValidateOpts{AllowInvalidAmounts: true})
+	require.NoError(t, ed.Validate())
+}
+
+func TestBatch__PrenoteAmountNonZero(t *testing.T) {
+	for _, sec := range []string{PPD, CCD, WEB} {
+		bh := mockBatchPPDHeader()
+		bh.StandardEntryClassCode = sec
+		ed := mockPPDEntryDetail()
+		ed.TransactionCode = CheckingPrenoteCredit
+		ed.Amount = 100
+		if sec == WEB {
+			ed.DiscretionaryData = "S"
+		}
+
+		batch, err := NewBatch(bh)
+		require.NoError(t, err)
+		batch.AddEntry(ed)
+
+		err = batch.Create()
+		require.ErrorIs(t, err, ErrBatchAmountNonZero, sec)
+	}
+}
+
+func TestEntryDetail__EachAddenda(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.AddAddenda05(mockAddenda05())
+	ed.AddAddenda05(mockAddenda05())
+	ed.Addenda99 = mockAddenda99()
+
+	var types []string
+	err := ed.EachAddenda(func(a Addendumer) error {
+		types = append(types, fmt.Sprintf("%T", a))
+		if a05, ok := a.(*Addenda05); ok {
+			a05.PaymentRelatedInformation = ""
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*ach.Addenda05", "*ach.Addenda05", "*ach.Addenda99"}, types)
+	for _, a05 := range ed.Addenda05 {
+		require.Empty(t, a05.PaymentRelatedInformation)
+	}
+
+	// Errors stop iteration
+	var calls int
+	stop := errors.New("stop")
+	err = ed.EachAddenda(func(a Addendumer) error {
+		calls++
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, 1, calls)
+
+	// Replace the second Addenda05
+	second := ed.Addenda05[1]
+	replacement := mockAddenda05()
+	require.NoError(t, ed.ReplaceAddenda(second, replacement))
+	require.Same(t, replacement, ed.Addenda05[1])
+
+	require.Error(t, ed.ReplaceAddenda(second, mockAddenda05()))
+	require.Error(t, ed.ReplaceAddenda(ed.Addenda99, mockAddenda05()))
+	require.Error(t, ed.ReplaceAddenda(mockAddenda02(), mockAddenda02()))
+}
@@ -41,6 +41,8 @@ var (
 	ErrSECCode = errors.New("is an invalid Standard Entry Class Code")
 	//ErrOrigStatusCode is given when there's an invalid originator status code
 	ErrOrigStatusCode = errors.New("is an invalid Originator Status Code")
+	//ErrCompanyIdentificationPrefix is given when a company identification has an invalid prefix
+	ErrCompanyIdentificationPrefix = errors.New("is an invalid Company Identification prefix")
 	//ErrAddendaTypeCode is given when there's an invalid addenda type code
 	ErrAddendaTypeCode = errors.New("is an invalid Addenda Type Code")
 	//ErrTransactionCode is given when there's an invalid transaction code
@@ -55,6 +57,26 @@ var (
 	ErrValidDay = errors.New("is an invalid day")
 	//ErrValidYear is given when there's an invalid year
 	ErrValidYear = errors.New("is an invalid year")
+	//ErrAddendaRecordIndicator is given when an addenda record indicator is not 0 or 1
+	ErrAddendaRecordIndicator = errors.New("is an invalid Addenda Record Indicator")
+	// ErrAddendaRecordIndicatorNoAddenda is given when an addenda record indicator is 1 but there are no addenda records
+	ErrAddendaRecordIndicatorNoAddenda = errors.New("is 1 but no addenda records were found")
+	//ErrRoutingNumberRequired is given when a routing number is blank
+	ErrRoutingNumberRequired = errors.New("no routing number provided")
+	//ErrRoutingNumberLength is given when a routing number is not 9 digits
+	ErrRoutingNumberLength = errors.New("invalid routing number length")
+	//ErrRoutingNumberChecksum is given when the check digit of a routing number does not match the calculated one
+	ErrRoutingNumberChecksum = errors.New("routing number checksum mismatch")
+	//ErrValidDate is given when there's an invalid date
+	ErrValidDate = errors.New("is an invalid date")
+	//ErrValidTime is given when there's an invalid time
+	ErrValidTime = errors.New("is an invalid time")
+	//ErrValidJulianDay is given when there's an invalid Julian day of the year
+	ErrValidJulianDay = errors.New("is an invalid Julian day")
+	//ErrEffectiveEntryDatePast is given when an effective entry date is before the current day
+	ErrEffectiveEntryDatePast = errors.New("is in the past")
+	//ErrEffectiveEntryDateFuture is given when an effective entry date is too far in the future
+	ErrEffectiveEntryDateFuture = errors.New("is too far in the future")
 	// ErrValidState is the error given when a field has an invalid US state or territory
 	ErrValidState = errors.New("is an invalid US state or territory")
 	// ErrValidISO3166 is the error given when a field has an invalid ISO 3166-1-alpha-2 code
@@ -80,6 +102,8 @@ var (
 	ErrAddenda98CorrectedData = errors.New("must contain the corrected information corresponding to the Change Code")
 	// ErrAddenda99ReturnCode is given when there's an invalid return code
 	ErrAddenda99ReturnCode = errors.New("found is not a valid return code")
+	// ErrAddenda99ReturnTimeliness is given when a return is made after the time frame allowed for its return code
+	ErrAddenda99ReturnTimeliness = errors.New("is returned after the time frame allowed for the return code")
 	// ErrAddenda99DishonoredReturnCode is given when there's an invalid dishonored return code
 	ErrAddenda99DishonoredReturnCode = errors.New("found is not a valid dishonored return code")
 	// ErrAddenda99ContestedReturnCode is given when there's an invalid dishonored return code
@@ -112,10 +136,82 @@ var (
 
 // FieldError is returned for errors at a field level in a record
 type FieldError struct {
-	FieldName string      // field name where error happened
-	Value     interface{} // value that cause error
-	Err       error       // context of the error.
-	Msg       string      // deprecated
+	FieldName string         // field name where error happened
+	Value     interface{}    // value that cause error
+	Err       error          // context of the error.
+	Code      FieldErrorCode // classification of Err
+	Msg       string         // deprecated
+}
+
+// FieldErrorCode classifies the kind of failure of a FieldError for programmatic handling.
+type FieldErrorCode string
+
+const (
+	// ErrCodeUnknown is used for errors without a more specific code
+	ErrCodeUnknown FieldErrorCode = ""
+	// ErrCodeFieldInclusion is used when a mandatory field is missing or has a default value
+	ErrCodeFieldInclusion FieldErrorCode = "field-inclusion"
+	// ErrCodeFieldLength is used when a field does not have the correct length
+	ErrCodeFieldLength FieldErrorCode = "field-length"
+	// ErrCodeCharacters is used when a field has characters which are not allowed
+	ErrCodeCharacters FieldErrorCode = "characters"
+	// ErrCodeCheckDigit is used when a check digit does not match the calculated one
+	ErrCodeCheckDigit FieldErrorCode = "check-digit"
+	// ErrCodeRoutingNumber is used when a routing number is blank or has the wrong length
+	ErrCodeRoutingNumber FieldErrorCode = "routing-number"
+	// ErrCodeDate is used when a date or time field is invalid
+	ErrCodeDate FieldErrorCode = "date"
+	// ErrCodeServiceClass is used for an invalid Service Class Code
+	ErrCodeServiceClass FieldErrorCode = "service-class"
+	// ErrCodeSECCode is used for an invalid Standard Entry Class Code
+	ErrCodeSECCode FieldErrorCode = "sec-code"
+	// ErrCodeTransactionCode is used for an invalid Transaction Code
+	ErrCodeTransactionCode FieldErrorCode = "transaction-code"
+	// ErrCodeAddendaTypeCode is used for an invalid Addenda Type Code or Addenda Record Indicator
+	ErrCodeAddendaTypeCode FieldErrorCode = "addenda-type-code"
+	// ErrCodeReturnCode is used for invalid return, dishonored return or change codes
+	ErrCodeReturnCode FieldErrorCode = "return-code"
+	// ErrCodeAmount is used for invalid amounts
+	ErrCodeAmount FieldErrorCode = "amount"
+)
+
+// fieldErrorCode returns the FieldErrorCode for err
+func fieldErrorCode(err error) FieldErrorCode {
+	var checkDigit ErrValidCheckDigit
+	var fieldLength ErrValidFieldLength
+	var minFieldLength ErrMinFieldLength
+	var serviceClassTranCode ErrBatchServiceClassTranCode
+	switch {
+	case errors.Is(err, ErrFieldInclusion), errors.Is(err, ErrConstructor), errors.Is(err, ErrFieldRequired):
+		return ErrCodeFieldInclusion
+	case errors.As(err, &fieldLength), errors.As(err, &minFieldLength):
+		return ErrCodeFieldLength
+	case errors.Is(err, ErrNonAlphanumeric), errors.Is(err, ErrUpperAlpha):
+		return ErrCodeCharacters
+	case errors.As(err, &checkDigit), errors.Is(err, ErrRoutingNumberChecksum):
+		return ErrCodeCheckDigit
+	case errors.Is(err, ErrRoutingNumberRequired), errors.Is(err, ErrRoutingNumberLength):
+		return ErrCodeRoutingNumber
+	case errors.Is(err, ErrValidMonth), errors.Is(err, ErrValidDay), errors.Is(err, ErrValidYear),
+		errors.Is(err, ErrValidDate), errors.Is(err, ErrValidTime), errors.Is(err, ErrValidJulianDay),
+		errors.Is(err, ErrEffectiveEntryDatePast), errors.Is(err, ErrEffectiveEntryDateFuture):
+		return ErrCodeDate
+	case errors.Is(err, ErrServiceClass), errors.As(err, &serviceClassTranCode):
+		return ErrCodeServiceClass
+	case errors.Is(err, ErrSECCode):
+		return ErrCodeSECCode
+	case errors.Is(err, ErrTransactionCode):
+		return ErrCodeTransactionCode
+	case errors.Is(err, ErrAddendaTypeCode), errors.Is(err, ErrAddendaRecordIndicator):
+		return ErrCodeAddendaTypeCode
+	case errors.Is(err, ErrAddenda99ReturnCode), errors.Is(err, ErrAddenda99DishonoredReturnCode),
+		errors.Is(err, ErrAddenda99ContestedReturnCode), errors.Is(err, ErrAddenda98ChangeCode),
+		errors.Is(err, ErrAddenda98RefusedChangeCode):
+		return ErrCodeReturnCode
+	case errors.Is(err, ErrNegativeAmount):
+		return ErrCodeAmount
+	}
+	return ErrCodeUnknown
 }
 
 // Error message is constructed
@@ -141,6 +237,7 @@ func fieldError(field string, err error, values ...interface{}) error {
 	fe := FieldError{
 		FieldName: field,
 		Err:       err,
+		Code:      fieldErrorCode(err),
 	}
 	// only the first value counts
 	if len(values) > 0 {
@@ -149,6 +246,14 @@ func fieldError(field string, err error, values ...interface{}) error {
 	return &fe
 }
 
+// appendError adds err onto errs when it is non-nil
+func appendError(errs []error, err error) []error {
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 // ErrValidCheckDigit is the error given when the observed check digit does not match the calculated one
 type ErrValidCheckDigit struct {
 	Message              string
@@ -185,6 +290,24 @@ func (e ErrValidFieldLength) Error() string {
 	return e.Message
 }
 
+// ErrMinFieldLength is the error given when the field is shorter than a required minimum length
+type ErrMinFieldLength struct {
+	Message   string
+	MinLength int
+}
+
+// NewErrMinFieldLength creates a new error of the ErrMinFieldLength type
+func NewErrMinFieldLength(minLength int) ErrMinFieldLength {
+	return ErrMinFieldLength{
+		Message:   fmt.Sprintf("is shorter than %v characters", minLength),
+		MinLength: minLength,
+	}
+}
+
+func (e ErrMinFieldLength) Error() string {
+	return e.Message
+}
+
 // ErrRecordType is the error given when the field does not have the right record type
 type ErrRecordType struct {
 	Message      string
@@ -0,0 +1,63 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldError__Code(t *testing.T) {
+	ed := mockEntryDetail()
+	ed.CheckDigit = "1"
+	err := ed.Validate()
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, ErrCodeCheckDigit, fe.Code)
+	require.ErrorAs(t, errors.Unwrap(err), &ErrValidCheckDigit{})
+
+	cases := map[error]FieldErrorCode{
+		ErrConstructor:                   ErrCodeFieldInclusion,
+		NewErrValidFieldLength(23):       ErrCodeFieldLength,
+		ErrNonAlphanumeric:               ErrCodeCharacters,
+		ErrRoutingNumberChecksum:         ErrCodeCheckDigit,
+		ErrRoutingNumberLength:           ErrCodeRoutingNumber,
+		ErrValidMonth:                    ErrCodeDate,
+		ErrServiceClass:                  ErrCodeServiceClass,
+		ErrSECCode:                       ErrCodeSECCode,
+		ErrTransactionCode:               ErrCodeTransactionCode,
+		ErrAddendaTypeCode:               ErrCodeAddendaTypeCode,
+		ErrAddenda99ReturnCode:           ErrCodeReturnCode,
+		ErrNegativeAmount:                ErrCodeAmount,
+		errors.New("something happened"): ErrCodeUnknown,
+	}
+	for err, code := range cases {
+		fe, ok := fieldError("Field", err, "value").(*FieldError)
+		require.True(t, ok)
+		require.Equal(t, code, fe.Code, err.Error())
+	}
+
+	// Wrapped sentinel errors are classified
+	fh := mockFileHeader()
+	fh.ImmediateDestination = "231380105"
+	require.ErrorAs(t, fh.Validate(), &fe)
+	require.Equal(t, ErrCodeCheckDigit, fe.Code)
+}
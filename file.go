@@ -22,7 +22,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -112,6 +114,15 @@ func FileFromJSON(bs []byte) (*File, error) {
 	return FileFromJSONWith(bs, nil)
 }
 
+// ReadJSON reads all of r and parses the contents as a JSON formatted ACH file with FileFromJSON.
+func ReadJSON(r io.Reader) (*File, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return FileFromJSON(bs)
+}
+
 // ReadJSONFile will consume the specified filepath and parse the contents as a JSON formatted ACH file.
 func ReadJSONFile(path string) (*File, error) {
 	bs, err := os.ReadFile(path)
@@ -232,6 +243,12 @@ func (f *File) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// WriteJSON writes the File and its validation settings to w as a single line of JSON, the same
+// document produced by MarshalJSON. The output can be read back with FileFromJSON.
+func (f *File) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(f)
+}
+
 // UnmarshalJSON parses a JSON blob with ach.FileFromJSON
 func (f *File) UnmarshalJSON(p []byte) error {
 	file, err := FileFromJSONWith(p, f.validateOpts)
@@ -500,7 +517,8 @@ func datetimeParse(v string) (time.Time, error) {
 // Create requires a FileHeader and at least one Batch if validateOpts.AllowZeroBatches is false.
 //
 // Since each Batch may modify computable fields in the File, any calls to
-// Batch.Create should be done before Create.
+// Batch.Create should be done before Create. Use CreateAll to have each
+// Batch and IATBatch created first.
 //
 // To check if the File is Nacha compliant, call Validate or ValidateWith.
 func (f *File) Create() error {
@@ -590,6 +608,22 @@ func (f *File) Create() error {
 	return nil
 }
 
+// CreateAll calls Create on each Batch and IATBatch and then on the File,
+// assembling a whole file from populated headers and entries in one call.
+func (f *File) CreateAll() error {
+	for _, batch := range f.Batches {
+		if err := batch.Create(); err != nil {
+			return err
+		}
+	}
+	for i := range f.IATBatches {
+		if err := f.IATBatches[i].Create(); err != nil {
+			return err
+		}
+	}
+	return f.Create()
+}
+
 // AddBatch appends a Batch to the ach.File
 func (f *File) AddBatch(batch Batcher) []Batcher {
 	if batch == nil {
@@ -637,6 +671,122 @@ func (f *File) AddIATBatch(iatBatch IATBatch) []IATBatch {
 	return f.IATBatches
 }
 
+// EachEntry calls fn with the index of the Batch and each EntryDetail in the File, in order.
+// Iteration stops at and returns the first error returned by fn. IATBatches and nil batches are not included.
+func (f *File) EachEntry(fn func(batchIndex int, ed *EntryDetail) error) error {
+	if f == nil || fn == nil {
+		return nil
+	}
+	for i := range f.Batches {
+		if f.Batches[i] == nil {
+			continue
+		}
+		for _, entry := range f.Batches[i].GetEntries() {
+			if err := fn(i, entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AllEntries returns every EntryDetail from each Batch in the File, in order.
+// IATBatches and nil batches are not included.
+func (f *File) AllEntries() []*EntryDetail {
+	var out []*EntryDetail
+	f.EachEntry(func(_ int, ed *EntryDetail) error {
+		out = append(out, ed)
+		return nil
+	})
+	return out
+}
+
+// BatchSummary returns the number of entries in the File keyed by StandardEntryClassCode,
+// including ADV entries and IAT batches.
+func (f *File) BatchSummary() map[string]int {
+	out := make(map[string]int)
+	if f == nil {
+		return out
+	}
+	for _, batch := range f.Batches {
+		if bh := batch.GetHeader(); bh != nil {
+			out[bh.StandardEntryClassCode] += len(batch.GetEntries()) + len(batch.GetADVEntries())
+		}
+	}
+	for i := range f.IATBatches {
+		if bh := f.IATBatches[i].GetHeader(); bh != nil {
+			out[bh.StandardEntryClassCode] += len(f.IATBatches[i].GetEntries())
+		}
+	}
+	return out
+}
+
+// CategoryCounts returns the number of forward, return and notification of change entries in the File.
+// Entries without a Category are counted as forward entries.
+func (f *File) CategoryCounts() (forward, returns, noc int) {
+	if f == nil {
+		return 0, 0, 0
+	}
+	count := func(category string) {
+		switch category {
+		case CategoryReturn:
+			returns++
+		case CategoryNOC:
+			noc++
+		default:
+			forward++
+		}
+	}
+	for _, batch := range f.Batches {
+		for _, entry := range batch.GetEntries() {
+			count(entry.Category)
+		}
+		for _, entry := range batch.GetADVEntries() {
+			count(entry.Category)
+		}
+	}
+	for i := range f.IATBatches {
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			count(entry.Category)
+		}
+	}
+	return forward, returns, noc
+}
+
+// FindEntryByTrace returns the first EntryDetail with the given TraceNumber and the Batch holding it.
+// Leading zeros are ignored so an Addenda99 OriginalTrace can be passed directly.
+func (f *File) FindEntryByTrace(trace string) (*EntryDetail, Batcher, bool) {
+	if f == nil {
+		return nil, nil, false
+	}
+	trace = f.Header.stringField(strings.TrimSpace(trace), 15)
+	for _, batch := range f.Batches {
+		for _, entry := range batch.GetEntries() {
+			if entry.TraceNumberField() == trace {
+				return entry, batch, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// FindAllEntriesByTrace returns each EntryDetail with the given TraceNumber. TraceNumbers should be
+// unique in a File so more than one result indicates duplicates.
+func (f *File) FindAllEntriesByTrace(trace string) []*EntryDetail {
+	if f == nil {
+		return nil
+	}
+	trace = f.Header.stringField(strings.TrimSpace(trace), 15)
+	var out []*EntryDetail
+	f.EachEntry(func(_ int, ed *EntryDetail) error {
+		if ed.TraceNumberField() == trace {
+			out = append(out, ed)
+		}
+		return nil
+	})
+	return out
+}
+
 // SetHeader allows for header to be built.
 func (f *File) SetHeader(h FileHeader) *File {
 	f.Header = h
@@ -663,6 +813,9 @@ func (f *File) GetValidation() *ValidateOpts {
 
 // SetValidation stores ValidateOpts on the File which are to be used to override
 // the default NACHA validation rules.
+//
+// Non-nil opts are also stored on each Batch and IATBatch currently in the File so
+// rules such as AllowInvalidCheckDigit apply to every EntryDetail on the next Validate.
 func (f *File) SetValidation(opts *ValidateOpts) {
 	if f == nil {
 		return
@@ -670,6 +823,18 @@ func (f *File) SetValidation(opts *ValidateOpts) {
 
 	f.validateOpts = opts
 	f.Header.SetValidation(opts)
+
+	if opts == nil {
+		return
+	}
+	for i := range f.Batches {
+		if f.Batches[i] != nil {
+			f.Batches[i].SetValidation(opts)
+		}
+	}
+	for i := range f.IATBatches {
+		f.IATBatches[i].SetValidation(opts)
+	}
 }
 
 // ValidateOpts contains specific overrides from the default set of validations
@@ -746,6 +911,45 @@ type ValidateOpts struct {
 
 	// AllowZeroEntryAmount will skip enforcing the entry Amount to be non-zero
 	AllowZeroEntryAmount bool `json:"allowZeroEntryAmount"`
+
+	// RequireMatchingOrigin can be set to require the ODFIIdentification of each batch to
+	// match the routing number in the ImmediateOrigin file header field.
+	RequireMatchingOrigin bool `json:"requireMatchingOrigin"`
+
+	// RequireCompanyIdentificationPrefix can be set to require the CompanyIdentification of each
+	// BatchHeader to start with a NACHA identification prefix (1, 3 or 9).
+	RequireCompanyIdentificationPrefix bool `json:"requireCompanyIdentificationPrefix"`
+
+	// AllowInvalidBlockCount skips checking the BlockCount in the FileControl against the number of records.
+	AllowInvalidBlockCount bool `json:"allowInvalidBlockCount"`
+
+	// SanitizeNames transliterates accented letters and replaces characters which are not alphanumeric
+	// with a space in IndividualName and CompanyName when records are written.
+	SanitizeNames bool `json:"sanitizeNames"`
+
+	// RequireWEBPaymentType rejects WEB entries with a blank payment type instead of
+	// defaulting them to S (single) in Create, and only allows person-to-person (single) credits.
+	RequireWEBPaymentType bool `json:"requireWEBPaymentType"`
+
+	// RequireCreditsOrDebitsOnly rejects batches which contain both credit and debit entries,
+	// as some ODFIs do not accept mixed batches.
+	RequireCreditsOrDebitsOnly bool `json:"requireCreditsOrDebitsOnly"`
+
+	// RequireBatches rejects files without any batches in Validate, such as an accidental
+	// empty file with only a FileHeader and FileControl.
+	RequireBatches bool `json:"requireBatches"`
+
+	// RequireIATAddendaRecords rejects IAT entries whose AddendaRecords field does not match the
+	// number of addenda records on the entry.
+	RequireIATAddendaRecords bool `json:"requireIATAddendaRecords"`
+
+	// MinIndividualNameLength rejects entries in PPD, WEB, TEL and CIE batches whose trimmed
+	// IndividualName is shorter than this many characters. Zero only requires a non-blank name.
+	MinIndividualNameLength int `json:"minIndividualNameLength"`
+
+	// TruncatePaymentRelatedInformation allows Addenda05 PaymentRelatedInformation longer than
+	// 80 characters, which is truncated to 80 characters when written.
+	TruncatePaymentRelatedInformation bool `json:"truncatePaymentRelatedInformation"`
 }
 
 // merge will combine two ValidateOpts structs and keep any non-zero field values.
@@ -759,22 +963,33 @@ func (v *ValidateOpts) merge(other *ValidateOpts) *ValidateOpts {
 	}
 
 	out := &ValidateOpts{
-		SkipAll:                          v.SkipAll || other.SkipAll,
-		RequireABAOrigin:                 v.RequireABAOrigin || other.RequireABAOrigin,
-		BypassOriginValidation:           v.BypassOriginValidation || other.BypassOriginValidation,
-		BypassDestinationValidation:      v.BypassDestinationValidation || other.BypassDestinationValidation,
-		CustomTraceNumbers:               v.CustomTraceNumbers || other.CustomTraceNumbers,
-		AllowZeroBatches:                 v.AllowZeroBatches || other.AllowZeroBatches,
-		AllowMissingFileHeader:           v.AllowMissingFileHeader || other.AllowMissingFileHeader,
-		AllowMissingFileControl:          v.AllowMissingFileControl || other.AllowMissingFileControl,
-		BypassCompanyIdentificationMatch: v.BypassCompanyIdentificationMatch || other.BypassCompanyIdentificationMatch,
-		CustomReturnCodes:                v.CustomReturnCodes || other.CustomReturnCodes,
-		UnequalServiceClassCode:          v.UnequalServiceClassCode || other.UnequalServiceClassCode,
-		AllowUnorderedBatchNumbers:       v.AllowUnorderedBatchNumbers || other.AllowUnorderedBatchNumbers,
-		AllowInvalidCheckDigit:           v.AllowInvalidCheckDigit || other.AllowInvalidCheckDigit,
-		UnequalAddendaCounts:             v.UnequalAddendaCounts || other.UnequalAddendaCounts,
-		PreserveSpaces:                   v.PreserveSpaces || other.PreserveSpaces,
-		AllowInvalidAmounts:              v.AllowInvalidAmounts || other.AllowInvalidAmounts,
+		SkipAll:                            v.SkipAll || other.SkipAll,
+		RequireABAOrigin:                   v.RequireABAOrigin || other.RequireABAOrigin,
+		BypassOriginValidation:             v.BypassOriginValidation || other.BypassOriginValidation,
+		BypassDestinationValidation:        v.BypassDestinationValidation || other.BypassDestinationValidation,
+		CustomTraceNumbers:                 v.CustomTraceNumbers || other.CustomTraceNumbers,
+		AllowZeroBatches:                   v.AllowZeroBatches || other.AllowZeroBatches,
+		AllowMissingFileHeader:             v.AllowMissingFileHeader || other.AllowMissingFileHeader,
+		AllowMissingFileControl:            v.AllowMissingFileControl || other.AllowMissingFileControl,
+		BypassCompanyIdentificationMatch:   v.BypassCompanyIdentificationMatch || other.BypassCompanyIdentificationMatch,
+		CustomReturnCodes:                  v.CustomReturnCodes || other.CustomReturnCodes,
+		UnequalServiceClassCode:            v.UnequalServiceClassCode || other.UnequalServiceClassCode,
+		AllowUnorderedBatchNumbers:         v.AllowUnorderedBatchNumbers || other.AllowUnorderedBatchNumbers,
+		AllowInvalidCheckDigit:             v.AllowInvalidCheckDigit || other.AllowInvalidCheckDigit,
+		UnequalAddendaCounts:               v.UnequalAddendaCounts || other.UnequalAddendaCounts,
+		PreserveSpaces:                     v.PreserveSpaces || other.PreserveSpaces,
+		AllowInvalidAmounts:                v.AllowInvalidAmounts || other.AllowInvalidAmounts,
+		AllowZeroEntryAmount:               v.AllowZeroEntryAmount || other.AllowZeroEntryAmount,
+		RequireMatchingOrigin:              v.RequireMatchingOrigin || other.RequireMatchingOrigin,
+		RequireCompanyIdentificationPrefix: v.RequireCompanyIdentificationPrefix || other.RequireCompanyIdentificationPrefix,
+		AllowInvalidBlockCount:             v.AllowInvalidBlockCount || other.AllowInvalidBlockCount,
+		SanitizeNames:                      v.SanitizeNames || other.SanitizeNames,
+		RequireWEBPaymentType:              v.RequireWEBPaymentType || other.RequireWEBPaymentType,
+		RequireCreditsOrDebitsOnly:         v.RequireCreditsOrDebitsOnly || other.RequireCreditsOrDebitsOnly,
+		RequireBatches:                     v.RequireBatches || other.RequireBatches,
+		RequireIATAddendaRecords:           v.RequireIATAddendaRecords || other.RequireIATAddendaRecords,
+		MinIndividualNameLength:            max(v.MinIndividualNameLength, other.MinIndividualNameLength),
+		TruncatePaymentRelatedInformation:  v.TruncatePaymentRelatedInformation || other.TruncatePaymentRelatedInformation,
 	}
 
 	if v.CheckTransactionCode != nil {
@@ -809,11 +1024,19 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 			return err
 		}
 	}
+	if opts.RequireMatchingOrigin {
+		if err := f.isOriginODFI(); err != nil {
+			return err
+		}
+	}
+	if opts.RequireBatches && len(f.Batches) == 0 && len(f.IATBatches) == 0 {
+		return ErrFileNoBatches
+	}
 
 	if !f.IsADV() {
 		// The value of the Batch Count Field is equal to the number of Company/Batch/Header Records in the file.
-		if f.Control.BatchCount != (len(f.Batches) + len(f.IATBatches)) {
-			return NewErrFileCalculatedControlEquality("BatchCount", len(f.Batches), f.Control.BatchCount)
+		if batchCount := len(f.Batches) + len(f.IATBatches); f.Control.BatchCount != batchCount {
+			return NewErrFileCalculatedControlEquality("BatchCount", batchCount, f.Control.BatchCount)
 		}
 
 		for _, b := range f.Batches {
@@ -833,6 +1056,11 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 		if err := f.isFileAmount(false); err != nil {
 			return err
 		}
+		if !opts.AllowMissingFileControl && !opts.AllowInvalidBlockCount {
+			if err := f.isBlockCount(false); err != nil {
+				return err
+			}
+		}
 		if !opts.AllowUnorderedBatchNumbers {
 			if err := f.isSequenceAscending(); err != nil {
 				return err
@@ -858,9 +1086,84 @@ func (f *File) ValidateWith(opts *ValidateOpts) error {
 	if err := f.isFileAmount(true); err != nil {
 		return err
 	}
+	if !opts.AllowMissingFileControl && !opts.AllowInvalidBlockCount {
+		if err := f.isBlockCount(true); err != nil {
+			return err
+		}
+	}
 	return f.isEntryHash(true)
 }
 
+// ValidateAll performs the same checks as Validate using the File's ValidateOpts, but returns
+// every error found in the File, its records and each Batch instead of stopping at the first.
+//
+// Batches are checked with their ValidateAll method, the SEC specific rules of a Batch are
+// only checked once each of its records is valid. IATBatches only report their first error.
+func (f *File) ValidateAll() []error {
+	opts := f.validateOpts
+	if opts == nil {
+		opts = &ValidateOpts{}
+	}
+	if opts.SkipAll {
+		return nil
+	}
+
+	var errs []error
+	if !opts.AllowMissingFileHeader {
+		errs = appendError(errs, f.Header.ValidateWith(opts))
+	}
+	if opts.RequireMatchingOrigin {
+		errs = appendError(errs, f.isOriginODFI())
+	}
+	if opts.RequireBatches && len(f.Batches) == 0 && len(f.IATBatches) == 0 {
+		errs = append(errs, ErrFileNoBatches)
+	}
+
+	isADV := f.IsADV()
+	if !isADV {
+		if batchCount := len(f.Batches) + len(f.IATBatches); f.Control.BatchCount != batchCount {
+			errs = append(errs, NewErrFileCalculatedControlEquality("BatchCount", batchCount, f.Control.BatchCount))
+		}
+	} else {
+		if f.ADVControl.BatchCount != len(f.Batches) {
+			errs = append(errs, NewErrFileCalculatedControlEquality("BatchCount", len(f.Batches), f.ADVControl.BatchCount))
+		}
+	}
+
+	for _, b := range f.Batches {
+		var batchErrs []error
+		if v, ok := b.(interface{ ValidateAll() []error }); ok {
+			batchErrs = v.ValidateAll()
+		}
+		if len(batchErrs) == 0 {
+			batchErrs = appendError(batchErrs, b.Validate())
+		}
+		errs = append(errs, batchErrs...)
+	}
+	for i := range f.IATBatches {
+		errs = appendError(errs, f.IATBatches[i].Validate())
+	}
+
+	if !opts.AllowMissingFileControl {
+		if !isADV {
+			errs = appendError(errs, f.Control.Validate())
+		} else {
+			errs = appendError(errs, f.ADVControl.Validate())
+		}
+	}
+	errs = appendError(errs, f.isEntryAddendaCount(isADV))
+	errs = appendError(errs, f.isFileAmount(isADV))
+	if !opts.AllowMissingFileControl && !opts.AllowInvalidBlockCount {
+		errs = appendError(errs, f.isBlockCount(isADV))
+	}
+	if !isADV && !opts.AllowUnorderedBatchNumbers {
+		errs = appendError(errs, f.isSequenceAscending())
+	}
+	errs = appendError(errs, f.isEntryHash(isADV))
+
+	return errs
+}
+
 // isEntryAddendaCount is prepared by hashing the RDFI's 8-digit Routing Number in each entry.
 // The Entry Hash provides a check against inadvertent alteration of data
 func (f *File) isEntryAddendaCount(IsADV bool) error {
@@ -899,6 +1202,134 @@ func (f *File) isEntryAddendaCount(IsADV bool) error {
 	return nil
 }
 
+// isBlockCount validates the BlockCount in the FileControl is the number of records in the
+// File divided by the blocking factor of 10, rounded up. Padding records are not counted
+// so files missing the trailing block padding still pass.
+func (f *File) isBlockCount(IsADV bool) error {
+	blockCount := f.Control.BlockCount
+	if IsADV {
+		blockCount = f.ADVControl.BlockCount
+	}
+	expected := blocksForRecords(f.recordCount(IsADV))
+	if blockCount != expected {
+		return NewErrFileCalculatedControlEquality("BlockCount", expected, blockCount)
+	}
+	return nil
+}
+
+// recordCount returns the number of records in the File without block padding, using the
+// EntryAddendaCount of each batch control.
+func (f *File) recordCount(IsADV bool) int {
+	// add 2 for FileHeader and FileControl
+	records := 2
+	if !IsADV {
+		for _, batch := range f.Batches {
+			records += 2 + batch.GetControl().EntryAddendaCount
+		}
+		for _, iatBatch := range f.IATBatches {
+			records += 2 + iatBatch.GetControl().EntryAddendaCount
+		}
+	} else {
+		for _, batch := range f.Batches {
+			records += 2 + batch.GetADVControl().EntryAddendaCount
+		}
+	}
+	return records
+}
+
+// blocksForRecords returns the number of blocks of 10 records needed to hold records
+func blocksForRecords(records int) int {
+	blocks := records / 10
+	if records%10 != 0 {
+		blocks++
+	}
+	return blocks
+}
+
+// BlockCount returns the number of blocks of 10 records the File occupies when written, including
+// the padding records which fill the final block. Batch controls must be tabulated with Create.
+func (f *File) BlockCount() int {
+	return blocksForRecords(f.recordCount(f.IsADV()))
+}
+
+// ByteSize returns the number of bytes the File occupies when written with the default line ending,
+// including padding records. See ByteSizeWithOpts for custom line endings.
+func (f *File) ByteSize() int {
+	return f.ByteSizeWithOpts(nil)
+}
+
+// ByteSizeWithOpts returns the number of bytes the File occupies when written by a Writer
+// created with NewWriterWithOpts(w, opts), including padding records and line endings.
+func (f *File) ByteSizeWithOpts(opts *WriteOpts) int {
+	lineEnding := "\n"
+	if opts != nil && opts.LineEnding != "" {
+		lineEnding = opts.LineEnding
+	}
+	return f.BlockCount() * 10 * (RecordLength + len(lineEnding))
+}
+
+// TotalCredits returns the sum in cents of every credit entry in the File, calculated from the
+// entries of each batch. It matches the FileControl TotalCreditEntryDollarAmountInFile of a balanced file.
+func (f *File) TotalCredits() int {
+	credit, _ := f.calculateEntryAmounts()
+	return credit
+}
+
+// TotalDebits returns the sum in cents of every debit entry in the File, calculated from the
+// entries of each batch. It matches the FileControl TotalDebitEntryDollarAmountInFile of a balanced file.
+func (f *File) TotalDebits() int {
+	_, debit := f.calculateEntryAmounts()
+	return debit
+}
+
+// TotalCreditsDollars returns TotalCredits in dollars
+func (f *File) TotalCreditsDollars() float64 {
+	return float64(f.TotalCredits()) / 100
+}
+
+// TotalDebitsDollars returns TotalDebits in dollars
+func (f *File) TotalDebitsDollars() float64 {
+	return float64(f.TotalDebits()) / 100
+}
+
+// calculateEntryAmounts sums the entries of every batch through the Batcher interface, so custom
+// Batcher implementations are included.
+func (f *File) calculateEntryAmounts() (credit int, debit int) {
+	for _, batch := range f.Batches {
+		if batch == nil {
+			continue
+		}
+		for _, entry := range batch.GetEntries() {
+			if entry == nil {
+				continue
+			}
+			switch entry.CreditOrDebit() {
+			case "C":
+				credit += entry.Amount
+			case "D":
+				debit += entry.Amount
+			}
+		}
+		for _, entry := range batch.GetADVEntries() {
+			if entry == nil {
+				continue
+			}
+			switch entry.TransactionCode {
+			case CreditForDebitsOriginated, CreditForCreditsReceived, CreditForCreditsRejected, CreditSummary:
+				credit += entry.Amount
+			case DebitForCreditsOriginated, DebitForDebitsReceived, DebitForDebitsRejectedBatches, DebitSummary:
+				debit += entry.Amount
+			}
+		}
+	}
+	for i := range f.IATBatches {
+		c, d := f.IATBatches[i].calculateBatchAmounts()
+		credit += c
+		debit += d
+	}
+	return credit, debit
+}
+
 // isFileAmount The Total Debit and Credit Entry Dollar Amounts Fields contain accumulated
 // Entry Detail debit and credit totals within the file
 func (f *File) isFileAmount(IsADV bool) error {
@@ -963,8 +1394,16 @@ func (f *File) isEntryHash(IsADV bool) error {
 	return nil
 }
 
+// entryHashCalculator is implemented by each batch type through Batch
+type entryHashCalculator interface {
+	calculateEntryHash() int
+}
+
 // calculateEntryHash This field is prepared by hashing the 8-digit Routing Number in each batch.
 // The Entry Hash provides a check against inadvertent alteration of data
+//
+// Each batch's hash is recalculated from its entries rather than read from its control record.
+// Batchers implemented outside this package fall back to their control's EntryHash.
 func (f *File) calculateEntryHash(IsADV bool) int {
 	// IsADV
 	// true: the file contains ADV batches
@@ -972,17 +1411,19 @@ func (f *File) calculateEntryHash(IsADV bool) int {
 
 	hash := 0
 
-	if !IsADV {
-		for _, batch := range f.Batches {
+	for _, batch := range f.Batches {
+		if b, ok := batch.(entryHashCalculator); ok {
+			hash = hash + b.calculateEntryHash()
+		} else if IsADV {
+			hash = hash + batch.GetADVControl().EntryHash
+		} else {
 			hash = hash + batch.GetControl().EntryHash
 		}
+	}
+	if !IsADV {
 		// IAT
-		for _, iatBatch := range f.IATBatches {
-			hash = hash + iatBatch.GetControl().EntryHash
-		}
-	} else {
-		for _, batch := range f.Batches {
-			hash = hash + batch.GetADVControl().EntryHash
+		for i := range f.IATBatches {
+			hash = hash + f.IATBatches[i].calculateEntryHash()
 		}
 	}
 
@@ -1320,6 +1761,55 @@ func segmentFileBatchAddADVEntry(creditBatch Batcher, debitBatch Batcher, entry
 	return nil
 }
 
+// SortBatches orders the File's batches by EffectiveEntryDate and then StandardEntryClassCode, keeping
+// the existing order of batches which are equal. BatchNumbers are reassigned from 1 on each BatchHeader
+// and BatchControl, continuing through the IAT batches which are sorted and written after the others.
+// Batches without a BatchHeader are moved to the end and are not numbered.
+func (f *File) SortBatches() {
+	header := func(b Batcher) *BatchHeader {
+		if b == nil {
+			return nil
+		}
+		return b.GetHeader()
+	}
+	sort.SliceStable(f.Batches, func(i, j int) bool {
+		bi, bj := header(f.Batches[i]), header(f.Batches[j])
+		if bi == nil || bj == nil {
+			return bi != nil
+		}
+		if bi.EffectiveEntryDate != bj.EffectiveEntryDate {
+			return bi.EffectiveEntryDate < bj.EffectiveEntryDate
+		}
+		return bi.StandardEntryClassCode < bj.StandardEntryClassCode
+	})
+	sort.SliceStable(f.IATBatches, func(i, j int) bool {
+		return f.IATBatches[i].GetHeader().EffectiveEntryDate < f.IATBatches[j].GetHeader().EffectiveEntryDate
+	})
+
+	batchNumber := 1
+	for _, batch := range f.Batches {
+		bh := header(batch)
+		if bh == nil {
+			continue
+		}
+		bh.BatchNumber = batchNumber
+		if bc := batch.GetControl(); bc != nil {
+			bc.BatchNumber = batchNumber
+		}
+		if bc := batch.GetADVControl(); bc != nil {
+			bc.BatchNumber = batchNumber
+		}
+		batchNumber++
+	}
+	for i := range f.IATBatches {
+		f.IATBatches[i].GetHeader().BatchNumber = batchNumber
+		if bc := f.IATBatches[i].GetControl(); bc != nil {
+			bc.BatchNumber = batchNumber
+		}
+		batchNumber++
+	}
+}
+
 // Validates that the batch numbers are ascending
 func (f *File) isSequenceAscending() error {
 	lastSeq := 0
@@ -1336,3 +1826,21 @@ func (f *File) isSequenceAscending() error {
 
 	return nil
 }
+
+// isOriginODFI validates that each batch's ODFIIdentification matches the routing number
+// in the FileHeader's ImmediateOrigin
+func (f *File) isOriginODFI() error {
+	origin := f.Header.stringField(strings.TrimSpace(f.Header.ImmediateOrigin), 8)
+
+	for _, batch := range f.Batches {
+		if odfi := batch.GetHeader().ODFIIdentificationField(); odfi != origin {
+			return batch.Error("ODFIIdentification", ErrFileODFIOrigin, odfi)
+		}
+	}
+	for i := range f.IATBatches {
+		if odfi := f.IATBatches[i].GetHeader().ODFIIdentificationField(); odfi != origin {
+			return f.IATBatches[i].Error("ODFIIdentification", ErrFileODFIOrigin, odfi)
+		}
+	}
+	return nil
+}
@@ -45,6 +45,14 @@ var (
 	ErrFileIATSEC = errors.New("IAT Standard Entry Class Code should use iatBatch")
 	// ErrFileNoBatches is the error given if a file has no batches
 	ErrFileNoBatches = errors.New("must have []*Batches or []*IATBatches to be built")
+	// ErrFileODFIOrigin is the error given if a batch's ODFIIdentification does not match the file's ImmediateOrigin
+	ErrFileODFIOrigin = errors.New("ODFIIdentification does not match ImmediateOrigin")
+	// ErrSplitMaxEntries is the error given when a file is split with a non-positive number of entries per file
+	ErrSplitMaxEntries = errors.New("max entries per file must be greater than zero")
+	// ErrSplitFileIDModifiers is the error given when splitting a file needs more FileIDModifier values than remain
+	ErrSplitFileIDModifiers = errors.New("split produced more files than FileIDModifier values")
+	// ErrSplitADVFile is the error given when an ADV file is split
+	ErrSplitADVFile = errors.New("ADV files cannot be split")
 
 	ErrInvalidJSON = errors.New("invalid JSON")
 )
@@ -139,11 +139,11 @@ func (fh *FileHeader) Parse(record string) {
 	// 35-37 Always "A"
 	fh.FileIDModifier = string(runes[33:34])
 	// 35-37 always "094"
-	fh.recordSize = "094"
+	fh.recordSize = string(runes[34:37])
 	// 38-39 always "10"
-	fh.blockingFactor = "10"
+	fh.blockingFactor = string(runes[37:39])
 	// 40 always "1"
-	fh.formatCode = "1"
+	fh.formatCode = string(runes[39:40])
 	// 41-63 The name of the ODFI. example "SILICON VALLEY BANK    "
 	fh.ImmediateDestinationName = fh.parseStringFieldWithOpts(string(runes[40:63]), fh.validateOpts)
 	// 64-86 ACH operator or sending point that is sending the file
@@ -230,6 +230,9 @@ func (fh *FileHeader) ValidateWith(opts *ValidateOpts) error {
 	if err := fh.isAlphanumeric(fh.ImmediateDestinationName); err != nil {
 		return fieldError("ImmediateDestinationName", err, fh.ImmediateDestinationName)
 	}
+	if utf8.RuneCountInString(fh.ImmediateDestinationName) > 23 {
+		return fieldError("ImmediateDestinationName", NewErrValidFieldLength(23), fh.ImmediateDestinationName)
+	}
 	if !opts.BypassOriginValidation {
 		if fh.ImmediateOrigin == zeroRoutingNumber9 || fh.ImmediateOrigin == zeroRoutingNumber10 {
 			return fieldError("ImmediateOrigin", ErrConstructor, fh.ImmediateOrigin)
@@ -251,6 +254,9 @@ func (fh *FileHeader) ValidateWith(opts *ValidateOpts) error {
 	if err := fh.isAlphanumeric(fh.ImmediateOriginName); err != nil {
 		return fieldError("ImmediateOriginName", err, fh.ImmediateOriginName)
 	}
+	if utf8.RuneCountInString(fh.ImmediateOriginName) > 23 {
+		return fieldError("ImmediateOriginName", NewErrValidFieldLength(23), fh.ImmediateOriginName)
+	}
 	if err := fh.isAlphanumeric(fh.ReferenceCode); err != nil {
 		return fieldError("ReferenceCode", err, fh.ReferenceCode)
 	}
@@ -303,7 +309,8 @@ func (fh *FileHeader) ImmediateDestinationField() string {
 	if fh.validateOpts != nil && fh.validateOpts.BypassDestinationValidation && len(fh.ImmediateDestination) == 10 {
 		return fh.ImmediateDestination
 	}
-	return " " + fh.stringField(fh.ImmediateDestination, 9)
+	// A routing number written with a leading 0 instead of a space keeps its 9 digits
+	return " " + fh.stringField(trimRoutingNumberLeadingZero(fh.ImmediateDestination), 9)
 }
 
 // ImmediateOriginField gets the immediate origin number with 0 padding
@@ -315,7 +322,8 @@ func (fh *FileHeader) ImmediateOriginField() string {
 	if fh.validateOpts != nil && fh.validateOpts.BypassOriginValidation && len(fh.ImmediateOrigin) == 10 {
 		return fh.ImmediateOrigin
 	}
-	return " " + fh.stringField(fh.ImmediateOrigin, 9)
+	// A routing number written with a leading 0 instead of a space keeps its 9 digits
+	return " " + fh.stringField(trimRoutingNumberLeadingZero(fh.ImmediateOrigin), 9)
 }
 
 // FileCreationDateField gets the file creation date in YYMMDD (year, month, day) format
@@ -352,6 +360,30 @@ func (fh *FileHeader) FileCreationTimeField() string {
 	return t.Format("1504") // HHmm
 }
 
+// SetCreation sets FileCreationDate (YYMMDD) and FileCreationTime (HHmm) from t. The values are
+// formatted in t's location, convert t with t.In first to write them in another time zone.
+func (fh *FileHeader) SetCreation(t time.Time) {
+	fh.FileCreationDate = t.Format("060102") // YYMMDD
+	fh.FileCreationTime = t.Format("1504")   // HHmm
+}
+
+// Creation returns the FileCreationDate and FileCreationTime as a time.Time in loc, or UTC when loc is nil.
+// The values are read as they would be written by FileCreationDateField and FileCreationTimeField.
+func (fh *FileHeader) Creation(loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	date, err := time.ParseInLocation("060102", fh.FileCreationDateField(), loc)
+	if err != nil {
+		return time.Time{}, fieldError("FileCreationDate", ErrValidDate, fh.FileCreationDate)
+	}
+	clock, err := time.ParseInLocation("1504", fh.FileCreationTimeField(), loc)
+	if err != nil {
+		return time.Time{}, fieldError("FileCreationTime", ErrValidTime, fh.FileCreationTime)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc), nil
+}
+
 // ImmediateDestinationNameField gets the ImmediateDestinationName field padded
 func (fh *FileHeader) ImmediateDestinationNameField() string {
 	return fh.alphaField(fh.ImmediateDestinationName, 23)
@@ -366,3 +398,19 @@ func (fh *FileHeader) ImmediateOriginNameField() string {
 func (fh *FileHeader) ReferenceCodeField() string {
 	return fh.alphaField(fh.ReferenceCode, 8)
 }
+
+// NextFileIDModifier advances FileIDModifier to the next value (A through Z then 0 through 9) for
+// another file sent on the same day for the same origin and destination. After 9 it wraps to A.
+// Lowercase letters advance as their uppercase value, so "a" becomes "B".
+func (fh *FileHeader) NextFileIDModifier() {
+	switch m := strings.ToUpper(fh.FileIDModifier); {
+	case len(m) != 1:
+		fh.FileIDModifier = "A"
+	case m[0] >= 'A' && m[0] < 'Z', m[0] >= '0' && m[0] < '9':
+		fh.FileIDModifier = string(m[0] + 1)
+	case m[0] == 'Z':
+		fh.FileIDModifier = "0"
+	default:
+		fh.FileIDModifier = "A"
+	}
+}
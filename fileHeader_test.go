@@ -825,3 +825,150 @@ func TestFileHeader__IsValidWithPreserveSpacesOpt(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFileHeader__NextFileIDModifier(t *testing.T) {
+	fh := mockFileHeader()
+	require.Equal(t, "A", fh.FileIDModifier)
+
+	var seen []string
+	for i := 0; i < 37; i++ {
+		fh.NextFileIDModifier()
+		require.NoError(t, fh.Validate())
+		seen = append(seen, fh.FileIDModifier)
+	}
+	require.Equal(t, "B", seen[0])
+	require.Equal(t, "Z", seen[24])
+	require.Equal(t, "0", seen[25])
+	require.Equal(t, "9", seen[34])
+	require.Equal(t, "A", seen[35])
+
+	fh.FileIDModifier = "a"
+	fh.NextFileIDModifier()
+	require.Equal(t, "B", fh.FileIDModifier)
+
+	fh.FileIDModifier = "z"
+	fh.NextFileIDModifier()
+	require.Equal(t, "0", fh.FileIDModifier)
+}
+
+func TestFileHeader__ReferenceCode(t *testing.T) {
+	fh := mockFileHeader()
+	fh.ReferenceCode = "PAYROLL"
+	require.NoError(t, fh.Validate())
+
+	line := fh.String()
+	require.Equal(t, "PAYROLL ", line[86:94])
+
+	parsed := NewFileHeader()
+	parsed.Parse(line)
+	require.Equal(t, "PAYROLL", parsed.ReferenceCode)
+}
+
+func TestFileHeader__NameLength(t *testing.T) {
+	fh := mockFileHeader()
+	fh.ImmediateOriginName = strings.Repeat("A", 23)
+	fh.ImmediateDestinationName = strings.Repeat("B", 23)
+	require.NoError(t, fh.Validate())
+	line := fh.String()
+	require.Equal(t, fh.ImmediateDestinationName, line[40:63])
+	require.Equal(t, fh.ImmediateOriginName, line[63:86])
+
+	fh.ImmediateOriginName = strings.Repeat("A", 30)
+	require.ErrorAs(t, fh.Validate(), &ErrValidFieldLength{})
+	require.Equal(t, strings.Repeat("A", 23), fh.ImmediateOriginNameField())
+
+	fh.ImmediateOriginName = "My Bank"
+	fh.ImmediateDestinationName = strings.Repeat("B", 30)
+	require.ErrorAs(t, fh.Validate(), &ErrValidFieldLength{})
+
+	fh.ImmediateDestinationName = "Federal\tReserve"
+	require.ErrorIs(t, fh.Validate(), ErrNonAlphanumeric)
+}
+
+func TestFileHeader__SetCreation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	when := time.Date(2024, time.March, 9, 23, 45, 12, 0, loc)
+	fh := mockFileHeader()
+	fh.SetCreation(when)
+	require.Equal(t, "240309", fh.FileCreationDate)
+	require.Equal(t, "2345", fh.FileCreationTime)
+	require.Equal(t, "2403092345", fh.String()[23:33])
+
+	created, err := fh.Creation(loc)
+	require.NoError(t, err)
+	require.True(t, when.Truncate(time.Minute).Equal(created))
+
+	// The record is written in the location of t
+	fh.SetCreation(when.In(time.UTC))
+	require.Equal(t, "240310", fh.FileCreationDate)
+	require.Equal(t, "0445", fh.FileCreationTime)
+
+	created, err = fh.Creation(nil)
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, created.Location())
+	require.True(t, when.Truncate(time.Minute).Equal(created))
+
+	fh.FileCreationDate = "241340"
+	_, err = fh.Creation(nil)
+	require.ErrorIs(t, err, ErrValidDate)
+
+	fh.SetCreation(when)
+	fh.FileCreationTime = "2599"
+	_, err = fh.Creation(nil)
+	require.ErrorIs(t, err, ErrValidTime)
+}
+
+func TestFileHeader__ParseRecordFormat(t *testing.T) {
+	mock := mockFileHeader()
+	line := mock.String()
+
+	fh := NewFileHeader()
+	fh.Parse(line)
+	require.NoError(t, fh.Validate())
+	require.Equal(t, "094101", line[34:40])
+
+	cases := []struct {
+		line string
+		err  error
+	}{
+		{line: line[:34] + "095" + line[37:], err: ErrRecordSize},
+		{line: line[:37] + "05" + line[39:], err: ErrBlockingFactor},
+		{line: line[:39] + "2" + line[40:], err: ErrFormatCode},
+	}
+	for _, tc := range cases {
+		fh := NewFileHeader()
+		fh.Parse(tc.line)
+		require.ErrorIs(t, fh.Validate(), tc.err)
+		require.Equal(t, tc.line, fh.String())
+
+		_, err := NewReader(strings.NewReader(tc.line)).Read()
+		require.ErrorContains(t, err, tc.err.Error())
+	}
+}
+
+func TestFileHeader__ImmediateDestinationLeadingSpace(t *testing.T) {
+	for _, dest := range []string{"231380104", " 231380104", "0231380104", "231380104 "} {
+		fh := staticFileHeader()
+		fh.ImmediateDestination = dest
+		fh.ImmediateOrigin = "0121042882"
+		require.Equal(t, " 231380104", fh.ImmediateDestinationField(), dest)
+		require.Equal(t, " 121042882", fh.ImmediateOriginField(), dest)
+
+		line := fh.String()
+		require.Len(t, line, RecordLength)
+		require.Equal(t, " 231380104", line[3:13])
+
+		read := NewFileHeader()
+		read.Parse(line)
+		require.Equal(t, "231380104", read.ImmediateDestination)
+		require.NoError(t, read.Validate())
+	}
+
+	// 10 character values are kept as-is when validation is bypassed
+	fh := staticFileHeader()
+	fh.SetValidation(&ValidateOpts{BypassDestinationValidation: true})
+	fh.ImmediateDestination = "0231380104"
+	require.Equal(t, "0231380104", fh.ImmediateDestinationField())
+}
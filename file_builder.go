@@ -0,0 +1,80 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// PaymentInstruction describes a single payment to build into an EntryDetail with BuildFile.
+type PaymentInstruction struct {
+	// Routing is the 9 digit routing number of the receiver's financial institution
+	Routing string `json:"routing"`
+	// Account is the receiver's account number
+	Account string `json:"account"`
+	// Name is the receiver's name, written as the IndividualName of the entry
+	Name string `json:"name"`
+	// AmountCents is the amount of the payment in cents
+	AmountCents int `json:"amountCents"`
+	// Checking selects a checking account, otherwise a savings account is used
+	Checking bool `json:"checking"`
+	// Credit creates a credit to the receiver's account, otherwise the account is debited
+	Credit bool `json:"credit"`
+}
+
+// transactionCode returns the TransactionCode for the account type and direction of the payment
+func (p PaymentInstruction) transactionCode() int {
+	switch {
+	case p.Checking && p.Credit:
+		return CheckingCredit
+	case p.Checking:
+		return CheckingDebit
+	case p.Credit:
+		return SavingsCredit
+	}
+	return SavingsDebit
+}
+
+// BuildFile creates a File with one batch holding an EntryDetail for each PaymentInstruction.
+//
+// Entries are given TransactionCodes from the account type and direction of each payment and
+// sequential TraceNumbers from the batch's ODFIIdentification. A zero ServiceClassCode is derived
+// from the payments by Create, which finalizes the batch and file and returns any validation error.
+func BuildFile(header FileHeader, bh BatchHeader, instructions []PaymentInstruction) (*File, error) {
+	batch, err := NewBatch(&bh)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range instructions {
+		entry := NewEntryDetail()
+		entry.TransactionCode = p.transactionCode()
+		entry.SetRDFI(p.Routing)
+		entry.DFIAccountNumber = p.Account
+		entry.IndividualName = p.Name
+		entry.Amount = p.AmountCents
+		entry.SetTraceNumber(bh.ODFIIdentification, i+1)
+		batch.AddEntry(entry)
+	}
+	if err := batch.Create(); err != nil {
+		return nil, err
+	}
+
+	file := NewFile().SetHeader(header)
+	file.AddBatch(batch)
+	if err := file.Create(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,65 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFile__PaymentInstructions(t *testing.T) {
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = 0
+	instructions := []PaymentInstruction{
+		{Routing: "231380104", Account: "123456789", Name: "Jane Doe", AmountCents: 150000, Checking: true, Credit: true},
+		{Routing: "121042882", Account: "987654321", Name: "John Doe", AmountCents: 2500, Credit: true},
+	}
+
+	file, err := BuildFile(mockFileHeader(), *bh, instructions)
+	require.NoError(t, err)
+	require.NoError(t, file.Validate())
+	require.Len(t, file.Batches, 1)
+	require.Equal(t, CreditsOnly, file.Batches[0].GetHeader().ServiceClassCode)
+	require.Equal(t, 152500, file.TotalCredits())
+
+	entries := file.Batches[0].GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, CheckingCredit, entries[0].TransactionCode)
+	require.Equal(t, "23138010", entries[0].RDFIIdentification)
+	require.Equal(t, "4", entries[0].CheckDigit)
+	require.Equal(t, "Jane Doe", entries[0].IndividualName)
+	require.Equal(t, SavingsCredit, entries[1].TransactionCode)
+	require.Equal(t, "121042880000002", entries[1].TraceNumber)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	// Debits are mixed into the batch
+	instructions = append(instructions, PaymentInstruction{Routing: "231380104", Account: "555", Name: "Payroll", AmountCents: 154000, Checking: true})
+	file, err = BuildFile(mockFileHeader(), *bh, instructions)
+	require.NoError(t, err)
+	require.Equal(t, MixedDebitsAndCredits, file.Batches[0].GetHeader().ServiceClassCode)
+	require.Equal(t, CheckingDebit, file.Batches[0].GetEntries()[2].TransactionCode)
+
+	// Invalid payments are rejected
+	instructions[0].Routing = "231380105"
+	_, err = BuildFile(mockFileHeader(), *bh, instructions)
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+)
+
+var csvHeaders = []string{
+	"SEC Code",
+	"Company Name",
+	"Trace Number",
+	"RDFI Routing Number",
+	"Account Number",
+	"Amount",
+	"Name",
+	"Credit/Debit",
+	"Effective Entry Date",
+	"Payment Related Information",
+}
+
+// WriteCSV writes one row for each EntryDetail in the File to w, after a row of column headers.
+//
+// Amounts are written in dollars (e.g. 12.34), the EffectiveEntryDate as YYYY-MM-DD and the
+// PaymentRelatedInformation of each Addenda05 is joined in the final column. IAT batches are not included.
+func (f *File) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeaders); err != nil {
+		return err
+	}
+	for _, batch := range f.Batches {
+		bh := batch.GetHeader()
+		if bh == nil {
+			continue
+		}
+		for _, entry := range batch.GetEntries() {
+			if err := cw.Write(csvEntryRow(bh, entry)); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvEntryRow(bh *BatchHeader, entry *EntryDetail) []string {
+	var direction string
+	switch entry.CreditOrDebit() {
+	case "C":
+		direction = "Credit"
+	case "D":
+		direction = "Debit"
+	}
+
+	effectiveDate := bh.EffectiveEntryDate
+	if t, err := time.Parse("060102", effectiveDate); err == nil {
+		effectiveDate = t.Format("2006-01-02")
+	}
+
+	var remittance []string
+	for _, addenda05 := range entry.Addenda05 {
+		if addenda05 != nil {
+			remittance = append(remittance, strings.TrimSpace(addenda05.PaymentRelatedInformation))
+		}
+	}
+
+	return []string{
+		bh.StandardEntryClassCode,
+		strings.TrimSpace(bh.CompanyName),
+		entry.TraceNumber,
+		entry.RDFIIdentification + entry.CheckDigit,
+		strings.TrimSpace(entry.DFIAccountNumber),
+		formatDollars(entry.Amount, false),
+		strings.TrimSpace(entry.IndividualName),
+		direction,
+		effectiveDate,
+		strings.Join(remittance, " "),
+	}
+}
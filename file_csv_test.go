@@ -0,0 +1,55 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"encoding/csv"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__WriteCSV(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	entry := file.Batches[0].GetEntries()[0]
+	entry.AddAddenda05(mockAddenda05())
+
+	var buf bytes.Buffer
+	require.NoError(t, file.WriteCSV(&buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, csvHeaders, rows[0])
+	require.Equal(t, []string{
+		"PPD",
+		"Name on Account",
+		"121042880000001",
+		"231380104",
+		"12345678",
+		"1000000.00",
+		"Receiver Account Name",
+		"Debit",
+		"2019-06-25",
+		"This is an Addenda05",
+	}, rows[1])
+}
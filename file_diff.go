@@ -0,0 +1,149 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// diffIgnoredFields are excluded from DiffFiles as they vary between otherwise identical files.
+var diffIgnoredFields = map[string]bool{
+	"ID":               true,
+	"FileCreationDate": true,
+	"FileCreationTime": true,
+	"FileIDModifier":   true,
+}
+
+// DiffFiles returns human-readable, field-level differences between two Files. An empty result
+// means both files carry the same financial content.
+//
+// Records are compared in file order. The ID fields, FileCreationDate, FileCreationTime and
+// FileIDModifier are ignored, matching what Hash excludes.
+func DiffFiles(a, b *File) []string {
+	if a == nil || b == nil {
+		if a != b {
+			return []string{"File: only one file is present"}
+		}
+		return nil
+	}
+
+	d := &differ{}
+	d.record("FileHeader", a.Header, b.Header)
+
+	d.length("Batches", len(a.Batches), len(b.Batches))
+	for i := 0; i < min(len(a.Batches), len(b.Batches)); i++ {
+		d.batch(fmt.Sprintf("Batches[%d]", i), a.Batches[i], b.Batches[i])
+	}
+
+	d.length("IATBatches", len(a.IATBatches), len(b.IATBatches))
+	for i := 0; i < min(len(a.IATBatches), len(b.IATBatches)); i++ {
+		d.record(fmt.Sprintf("IATBatches[%d]", i), a.IATBatches[i], b.IATBatches[i])
+	}
+
+	if a.IsADV() || b.IsADV() {
+		d.record("FileADVControl", a.ADVControl, b.ADVControl)
+	} else {
+		d.record("FileControl", a.Control, b.Control)
+	}
+	return d.diffs
+}
+
+type differ struct {
+	diffs []string
+}
+
+func (d *differ) add(path string, a, b interface{}) {
+	d.diffs = append(d.diffs, fmt.Sprintf("%s: %#v != %#v", path, a, b))
+}
+
+func (d *differ) length(path string, a, b int) {
+	if a != b {
+		d.diffs = append(d.diffs, fmt.Sprintf("%s: %d != %d records", path, a, b))
+	}
+}
+
+func (d *differ) batch(path string, a, b Batcher) {
+	if a == nil || b == nil {
+		if a != b {
+			d.diffs = append(d.diffs, fmt.Sprintf("%s: only one batch is present", path))
+		}
+		return
+	}
+	d.record(path+".Header", a.GetHeader(), b.GetHeader())
+
+	aEntries, bEntries := a.GetEntries(), b.GetEntries()
+	d.length(path+".Entries", len(aEntries), len(bEntries))
+	for i := 0; i < min(len(aEntries), len(bEntries)); i++ {
+		d.record(fmt.Sprintf("%s.Entries[%d]", path, i), aEntries[i], bEntries[i])
+	}
+
+	aADV, bADV := a.GetADVEntries(), b.GetADVEntries()
+	d.length(path+".ADVEntries", len(aADV), len(bADV))
+	for i := 0; i < min(len(aADV), len(bADV)); i++ {
+		d.record(fmt.Sprintf("%s.ADVEntries[%d]", path, i), aADV[i], bADV[i])
+	}
+
+	if a.GetHeader() != nil && a.GetHeader().StandardEntryClassCode == ADV {
+		d.record(path+".ADVControl", a.GetADVControl(), b.GetADVControl())
+	} else {
+		d.record(path+".Control", a.GetControl(), b.GetControl())
+	}
+}
+
+// record compares the exported fields of two records, descending into addenda
+// pointers and slices.
+func (d *differ) record(path string, a, b interface{}) {
+	d.value(path, reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func (d *differ) value(path string, a, b reflect.Value) {
+	if a.Kind() == reflect.Ptr || a.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.diffs = append(d.diffs, fmt.Sprintf("%s: only one record is present", path))
+			}
+			return
+		}
+		d.value(path, a.Elem(), b.Elem())
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || diffIgnoredFields[field.Name] {
+				continue
+			}
+			d.value(path+"."+field.Name, a.Field(i), b.Field(i))
+		}
+
+	case reflect.Slice:
+		d.length(path, a.Len(), b.Len())
+		for i := 0; i < min(a.Len(), b.Len()); i++ {
+			d.value(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Bool:
+		if a.Interface() != b.Interface() {
+			d.add(path, a.Interface(), b.Interface())
+		}
+	}
+}
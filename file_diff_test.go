@@ -0,0 +1,79 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFiles(t *testing.T) {
+	a, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	b, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	require.Empty(t, DiffFiles(a, b))
+
+	b.ID = "other"
+	b.Header.FileCreationDate = "991231"
+	b.Header.FileCreationTime = "2359"
+	b.Header.FileIDModifier = "Z"
+	require.Empty(t, DiffFiles(a, b))
+
+	b.Batches[0].GetEntries()[0].Amount += 1
+	b.Batches[0].GetEntries()[0].IndividualName = "Other Name"
+	b.Header.ImmediateOriginName = "Other Bank"
+
+	diffs := DiffFiles(a, b)
+	require.Len(t, diffs, 3)
+	require.Contains(t, diffs[0], "FileHeader.ImmediateOriginName")
+	require.Contains(t, diffs[1], "Batches[0].Entries[0].Amount")
+	require.Contains(t, diffs[2], `Batches[0].Entries[0].IndividualName: "Receiver Account Name " != "Other Name"`)
+}
+
+func TestDiffFiles__Structure(t *testing.T) {
+	a, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	b, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	entry := b.Batches[0].GetEntries()[0]
+	entry.AddAddenda05(mockAddenda05())
+	require.Equal(t, []string{"Batches[0].Entries[0].Addenda05: 0 != 1 records"}, DiffFiles(a, b))
+
+	b.Batches = nil
+	require.Contains(t, DiffFiles(a, b), "Batches: 1 != 0 records")
+
+	require.Empty(t, DiffFiles(nil, nil))
+	require.Len(t, DiffFiles(a, nil), 1)
+}
+
+func TestDiffFiles__IAT(t *testing.T) {
+	a, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	b, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	require.Empty(t, DiffFiles(a, b))
+
+	b.IATBatches[0].Entries[0].Addenda10.Name = "Other Name"
+	diffs := DiffFiles(a, b)
+	require.Len(t, diffs, 1)
+	require.Contains(t, diffs[0], "IATBatches[0].Entries[0].Addenda10.Name")
+}
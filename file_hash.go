@@ -0,0 +1,174 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"sort"
+)
+
+// Hash returns a stable SHA-256 (hex encoded) over the Nacha formatted records of a File.
+// Two files with the same financial content will return the same Hash, which helps detect
+// files which are transmitted or read more than once.
+//
+// The ID fields, FileCreationDate, FileCreationTime and FileIDModifier are excluded from the Hash
+// as they vary between otherwise identical files. Addenda records of each entry are hashed in
+// sorted order so their ordering does not change the result.
+func (f *File) Hash() string {
+	h := sha256.New()
+
+	header := f.Header
+	header.ID = ""
+	header.FileCreationDate = "000000"
+	header.FileCreationTime = "0000"
+	header.FileIDModifier = " "
+	writeHashLine(h, header.String())
+
+	for _, batch := range f.Batches {
+		if batch == nil {
+			continue
+		}
+		if bh := batch.GetHeader(); bh != nil {
+			writeHashLine(h, bh.String())
+		}
+		for _, entry := range batch.GetEntries() {
+			writeHashLine(h, entry.String())
+			writeHashLines(h, entryAddendaLines(entry))
+		}
+		for _, entry := range batch.GetADVEntries() {
+			writeHashLine(h, entry.String())
+			if entry.Addenda99 != nil {
+				writeHashLine(h, entry.Addenda99.String())
+			}
+		}
+		if batch.GetHeader() != nil && batch.GetHeader().StandardEntryClassCode == ADV {
+			if bc := batch.GetADVControl(); bc != nil {
+				writeHashLine(h, bc.String())
+			}
+		} else if bc := batch.GetControl(); bc != nil {
+			writeHashLine(h, bc.String())
+		}
+	}
+
+	for i := range f.IATBatches {
+		iatBatch := f.IATBatches[i]
+		if iatBatch.GetHeader() != nil {
+			writeHashLine(h, iatBatch.GetHeader().String())
+		}
+		for _, entry := range iatBatch.GetEntries() {
+			writeHashLine(h, entry.String())
+			writeHashLines(h, iatEntryAddendaLines(entry))
+		}
+		if iatBatch.GetControl() != nil {
+			writeHashLine(h, iatBatch.GetControl().String())
+		}
+	}
+
+	if f.IsADV() {
+		writeHashLine(h, f.ADVControl.String())
+	} else {
+		writeHashLine(h, f.Control.String())
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeHashLine(h hash.Hash, line string) {
+	h.Write([]byte(line))
+	h.Write([]byte("\n"))
+}
+
+// writeHashLines writes lines in sorted order so equivalent sets of records hash the same.
+func writeHashLines(h hash.Hash, lines []string) {
+	sort.Strings(lines)
+	for i := range lines {
+		writeHashLine(h, lines[i])
+	}
+}
+
+func entryAddendaLines(entry *EntryDetail) []string {
+	var lines []string
+	if entry.Addenda02 != nil {
+		lines = append(lines, entry.Addenda02.String())
+	}
+	for _, a := range entry.Addenda05 {
+		if a != nil {
+			lines = append(lines, a.String())
+		}
+	}
+	if entry.Addenda98 != nil {
+		lines = append(lines, entry.Addenda98.String())
+	}
+	if entry.Addenda98Refused != nil {
+		lines = append(lines, entry.Addenda98Refused.String())
+	}
+	if entry.Addenda99 != nil {
+		lines = append(lines, entry.Addenda99.String())
+	}
+	if entry.Addenda99Dishonored != nil {
+		lines = append(lines, entry.Addenda99Dishonored.String())
+	}
+	if entry.Addenda99Contested != nil {
+		lines = append(lines, entry.Addenda99Contested.String())
+	}
+	return lines
+}
+
+func iatEntryAddendaLines(entry *IATEntryDetail) []string {
+	var lines []string
+	if entry.Addenda10 != nil {
+		lines = append(lines, entry.Addenda10.String())
+	}
+	if entry.Addenda11 != nil {
+		lines = append(lines, entry.Addenda11.String())
+	}
+	if entry.Addenda12 != nil {
+		lines = append(lines, entry.Addenda12.String())
+	}
+	if entry.Addenda13 != nil {
+		lines = append(lines, entry.Addenda13.String())
+	}
+	if entry.Addenda14 != nil {
+		lines = append(lines, entry.Addenda14.String())
+	}
+	if entry.Addenda15 != nil {
+		lines = append(lines, entry.Addenda15.String())
+	}
+	if entry.Addenda16 != nil {
+		lines = append(lines, entry.Addenda16.String())
+	}
+	for _, a := range entry.Addenda17 {
+		if a != nil {
+			lines = append(lines, a.String())
+		}
+	}
+	for _, a := range entry.Addenda18 {
+		if a != nil {
+			lines = append(lines, a.String())
+		}
+	}
+	if entry.Addenda98 != nil {
+		lines = append(lines, entry.Addenda98.String())
+	}
+	if entry.Addenda99 != nil {
+		lines = append(lines, entry.Addenda99.String())
+	}
+	return lines
+}
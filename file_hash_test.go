@@ -0,0 +1,70 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Hash(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	expected := file.Hash()
+	require.Len(t, expected, 64)
+
+	again, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	again.ID = "other"
+	again.Header.FileCreationDate = "991231"
+	again.Header.FileCreationTime = "2359"
+	again.Header.FileIDModifier = "Z"
+	require.Equal(t, expected, again.Hash())
+
+	again.Batches[0].GetEntries()[0].Amount += 1
+	require.NotEqual(t, expected, again.Hash())
+}
+
+func TestFile_HashAddendaOrder(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	entry := file.Batches[0].GetEntries()[0]
+	a1, a2 := NewAddenda05(), NewAddenda05()
+	a1.PaymentRelatedInformation = "first"
+	a2.PaymentRelatedInformation = "second"
+	entry.AddAddenda05(a1)
+	entry.AddAddenda05(a2)
+	expected := file.Hash()
+
+	entry.Addenda05[0], entry.Addenda05[1] = entry.Addenda05[1], entry.Addenda05[0]
+	require.Equal(t, expected, file.Hash())
+}
+
+func TestFile_HashIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	require.NotEmpty(t, file.IATBatches)
+
+	expected := file.Hash()
+	file.IATBatches[0].Entries[0].Addenda10.ForeignPaymentAmount += 1
+	require.NotEqual(t, expected, file.Hash())
+}
@@ -0,0 +1,109 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+)
+
+// redactedName replaces the IndividualName of redacted entries
+const redactedName = "REDACTED"
+
+// Redact masks sensitive fields in the File so it can be logged or shared outside of production.
+//
+//   - DFIAccountNumber keeps only its last 4 characters, the rest are replaced with '*'
+//   - IndividualName is replaced with "REDACTED", it's a mandatory field so can't be blank. CTX, ATX
+//     and TRX entries only have the receiving company portion replaced, TRC and XCK entries store
+//     check data in IndividualName and are left as is.
+//   - Addenda05 and Addenda17 PaymentRelatedInformation is cleared
+//   - IAT receiver names (Addenda10) and street addresses (Addenda15) are replaced with "REDACTED"
+//     and the Addenda15 ReceiverIDNumber is cleared
+//
+// Amounts, routing numbers and trace numbers are kept, so controls and the file stay valid.
+// Company names and IDs, originator details (Addenda11-13), bank details (Addenda14, Addenda18)
+// and the receiver's city and country (Addenda16) are also kept.
+func (f *File) Redact() {
+	for _, b := range f.Batches {
+		if b == nil {
+			continue
+		}
+		var sec string
+		if bh := b.GetHeader(); bh != nil {
+			sec = bh.StandardEntryClassCode
+		}
+		for _, entry := range b.GetEntries() {
+			if entry == nil {
+				continue
+			}
+			entry.DFIAccountNumber = maskAccountNumber(entry.DFIAccountNumber)
+
+			switch sec {
+			case ATX, CTX, TRX:
+				// IndividualName holds the addenda record count ahead of the receiving company
+				entry.SetCATXReceivingCompany(redactedName)
+			case TRC, XCK:
+				// IndividualName holds the ProcessControlField and ItemResearchNumber
+			default:
+				entry.IndividualName = redactedName
+			}
+
+			for _, addenda05 := range entry.Addenda05 {
+				if addenda05 != nil {
+					addenda05.PaymentRelatedInformation = ""
+				}
+			}
+		}
+		for _, entry := range b.GetADVEntries() {
+			if entry == nil {
+				continue
+			}
+			entry.DFIAccountNumber = maskAccountNumber(entry.DFIAccountNumber)
+			entry.IndividualName = redactedName
+		}
+	}
+	for i := range f.IATBatches {
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			if entry == nil {
+				continue
+			}
+			entry.DFIAccountNumber = maskAccountNumber(entry.DFIAccountNumber)
+			if entry.Addenda10 != nil {
+				entry.Addenda10.Name = redactedName
+			}
+			if entry.Addenda15 != nil {
+				entry.Addenda15.ReceiverIDNumber = ""
+				entry.Addenda15.ReceiverStreetAddress = redactedName
+			}
+			for _, addenda17 := range entry.Addenda17 {
+				if addenda17 != nil {
+					addenda17.PaymentRelatedInformation = ""
+				}
+			}
+		}
+	}
+}
+
+// maskAccountNumber replaces all but the last 4 characters of an account number with '*'
+func maskAccountNumber(account string) string {
+	account = strings.TrimSpace(account)
+	runes := []rune(account)
+	if len(runes) <= 4 {
+		return account
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
@@ -0,0 +1,137 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__Redact(t *testing.T) {
+	paths := []string{
+		"ppd-debit.ach", "web-debit.ach", "iat-debit.ach", "return-WEB.ach",
+		"cor-example.ach", "rck.ach", "ppd-mixedDebitCredit.ach",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			file, err := ReadFile(filepath.Join("test", "testdata", path))
+			require.NoError(t, err)
+			require.NoError(t, file.Validate())
+
+			file.Redact()
+			require.NoError(t, file.Validate())
+
+			var buf bytes.Buffer
+			require.NoError(t, NewWriter(&buf).Write(file))
+			read, err := NewReader(&buf).Read()
+			require.NoError(t, err)
+			require.NoError(t, read.Validate())
+		})
+	}
+}
+
+func TestFile__RedactNilBatch(t *testing.T) {
+	file := mockFilePPD(t)
+	file.Batches = append(file.Batches, nil)
+
+	file.Redact()
+	require.Equal(t, redactedName, file.Batches[0].GetEntries()[0].IndividualName)
+}
+
+func TestFile__RedactFields(t *testing.T) {
+	file := mockFilePPD(t)
+	entry := file.Batches[0].GetEntries()[0]
+	entry.DFIAccountNumber = "123456789"
+	entry.AddAddenda05(mockAddenda05())
+	entry.AddendaRecordIndicator = 1
+	require.NoError(t, file.Batches[0].Create())
+	require.NoError(t, file.Create())
+
+	file.Redact()
+	require.Equal(t, "*****6789", entry.DFIAccountNumber)
+	require.Equal(t, "REDACTED", entry.IndividualName)
+	require.Empty(t, entry.Addenda05[0].PaymentRelatedInformation)
+	require.NoError(t, file.Validate())
+
+	// CTX entries keep their addenda count
+	ctx := NewFile().SetHeader(mockFileHeader())
+	ctx.AddBatch(mockBatchCTX(t))
+	require.NoError(t, ctx.Create())
+	count := ctx.Batches[0].GetEntries()[0].CATXAddendaRecordsField()
+
+	ctx.Redact()
+	require.Equal(t, count, ctx.Batches[0].GetEntries()[0].CATXAddendaRecordsField())
+	require.Equal(t, "REDACTED", strings.TrimSpace(ctx.Batches[0].GetEntries()[0].CATXReceivingCompanyField()))
+	require.NoError(t, ctx.Validate())
+
+	require.Equal(t, "1234", maskAccountNumber("1234"))
+	require.Equal(t, "*2345", maskAccountNumber(" 12345 "))
+}
+
+func TestFile__RedactPositionalLayouts(t *testing.T) {
+	trx := NewFile().SetHeader(mockFileHeader())
+	trx.AddBatch(mockBatchTRX(t))
+	require.NoError(t, trx.Create())
+	count := trx.Batches[0].GetEntries()[0].CATXAddendaRecordsField()
+
+	trx.Redact()
+	entry := trx.Batches[0].GetEntries()[0]
+	require.Equal(t, count, entry.CATXAddendaRecordsField())
+	require.Equal(t, "REDACTED", strings.TrimSpace(entry.CATXReceivingCompanyField()))
+	require.NoError(t, trx.Validate())
+
+	trc := NewFile().SetHeader(mockFileHeader())
+	trc.AddBatch(mockBatchTRC(t))
+	require.NoError(t, trc.Create())
+	name := trc.Batches[0].GetEntries()[0].IndividualName
+
+	trc.Redact()
+	require.Equal(t, name, trc.Batches[0].GetEntries()[0].IndividualName)
+	require.NoError(t, trc.Validate())
+}
+
+func TestFile__RedactADVAndIAT(t *testing.T) {
+	adv := mockFileADV(t)
+	adv.Batches[0].GetADVEntries()[0].DFIAccountNumber = "123456789"
+	adv.Redact()
+	require.Equal(t, "*****6789", adv.Batches[0].GetADVEntries()[0].DFIAccountNumber)
+	require.Equal(t, "REDACTED", adv.Batches[0].GetADVEntries()[0].IndividualName)
+	require.NoError(t, adv.Validate())
+
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	file.Redact()
+	entry := file.IATBatches[0].GetEntries()[0]
+	require.Equal(t, "REDACTED", entry.Addenda10.Name)
+	require.Equal(t, "REDACTED", entry.Addenda15.ReceiverStreetAddress)
+	require.Empty(t, entry.Addenda15.ReceiverIDNumber)
+	require.NotEmpty(t, entry.Addenda17)
+	for _, addenda17 := range entry.Addenda17 {
+		require.Empty(t, addenda17.PaymentRelatedInformation)
+	}
+	require.NoError(t, file.Validate())
+
+	// nil records are skipped
+	ppd := mockFilePPD(t)
+	ppd.Batches[0].GetEntries()[0].Addenda05 = []*Addenda05{nil}
+	require.NotPanics(t, ppd.Redact)
+}
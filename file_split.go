@@ -0,0 +1,262 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/base"
+)
+
+// SplitByEntryCount returns copies of the File where each holds at most max EntryDetail records.
+// Batches are kept in order and a batch with more entries than fit in the current file is continued
+// in the next. Every file is given the next FileIDModifier after f's (starting from A when f's is blank),
+// its batches are renumbered and all controls are recomputed with Create before being validated.
+//
+// The returned files share EntryDetail records with f. ADV files cannot be split.
+func (f *File) SplitByEntryCount(max int) ([]*File, error) {
+	if max <= 0 {
+		return nil, ErrSplitMaxEntries
+	}
+	if f.IsADV() {
+		return nil, ErrSplitADVFile
+	}
+
+	s := &fileSplitter{source: f}
+	for _, b := range f.Batches {
+		var current Batcher
+		for _, entry := range b.GetEntries() {
+			if s.count == max {
+				if err := s.closeBatch(current); err != nil {
+					return nil, err
+				}
+				current = nil
+				if err := s.closeFile(); err != nil {
+					return nil, err
+				}
+			}
+			if current == nil {
+				bh := *b.GetHeader() // don't let the BatchHeader escape and mutate
+				bh.ID = base.ID()
+				bh.BatchNumber = s.nextBatchNumber()
+				batch, err := NewBatch(&bh)
+				if err != nil {
+					return nil, err
+				}
+				current = batch
+			}
+			current.AddEntry(entry)
+			s.count++
+		}
+		if err := s.closeBatch(current); err != nil {
+			return nil, err
+		}
+	}
+	for i := range f.IATBatches {
+		var current *IATBatch
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			if s.count == max {
+				if err := s.closeIATBatch(current); err != nil {
+					return nil, err
+				}
+				current = nil
+				if err := s.closeFile(); err != nil {
+					return nil, err
+				}
+			}
+			if current == nil {
+				bh := *f.IATBatches[i].GetHeader()
+				bh.ID = base.ID()
+				bh.BatchNumber = s.nextBatchNumber()
+				batch := NewIATBatch(&bh)
+				current = &batch
+			}
+			current.AddEntry(entry)
+			s.count++
+		}
+		if err := s.closeIATBatch(current); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.closeFile(); err != nil {
+		return nil, err
+	}
+	return s.files, nil
+}
+
+// fileSplitter accumulates batches into the file being built and collects finished files
+type fileSplitter struct {
+	source  *File
+	current *File
+	count   int
+	files   []*File
+}
+
+func (s *fileSplitter) file() *File {
+	if s.current == nil {
+		s.current = NewFile()
+		s.current.Header = s.source.Header
+		s.current.Header.ID = s.current.ID
+		if s.source.validateOpts != nil {
+			s.current.SetValidation(s.source.validateOpts)
+		}
+	}
+	return s.current
+}
+
+func (s *fileSplitter) nextBatchNumber() int {
+	file := s.file()
+	return len(file.Batches) + len(file.IATBatches) + 1
+}
+
+func (s *fileSplitter) closeBatch(batch Batcher) error {
+	if batch == nil {
+		return nil
+	}
+	if s.source.validateOpts != nil {
+		batch.SetValidation(s.source.validateOpts)
+	}
+	if err := batch.Create(); err != nil {
+		return err
+	}
+	s.file().AddBatch(batch)
+	return nil
+}
+
+func (s *fileSplitter) closeIATBatch(batch *IATBatch) error {
+	if batch == nil {
+		return nil
+	}
+	if s.source.validateOpts != nil {
+		batch.SetValidation(s.source.validateOpts)
+	}
+	if err := batch.Create(); err != nil {
+		return err
+	}
+	s.file().AddIATBatch(*batch)
+	return nil
+}
+
+// closeFile finishes the current file with the next FileIDModifier and starts a new one
+func (s *fileSplitter) closeFile() error {
+	if s.current == nil {
+		return nil
+	}
+	file := s.current
+	s.current = nil
+	s.count = 0
+
+	// each file follows the previous one, starting after the source's modifier
+	modifier := s.source.Header.FileIDModifier
+	if n := len(s.files); n > 0 {
+		modifier = s.files[n-1].Header.FileIDModifier
+	}
+	// 9 is the last modifier, NextFileIDModifier would wrap it to A
+	if strings.ToUpper(modifier) == "9" {
+		return ErrSplitFileIDModifiers
+	}
+	// a blank or unknown source modifier starts the split files from A
+	next := FileHeader{FileIDModifier: modifier}
+	next.NextFileIDModifier()
+	file.Header.FileIDModifier = next.FileIDModifier
+
+	if err := file.Create(); err != nil {
+		return fmt.Errorf("creating split file %d: %w", len(s.files)+1, err)
+	}
+	if err := file.Validate(); err != nil {
+		return fmt.Errorf("validating split file %d: %w", len(s.files)+1, err)
+	}
+	s.files = append(s.files, file)
+	return nil
+}
+
+// SplitBySEC returns a File for each StandardEntryClassCode in f, keyed by the code. Each file has a
+// copy of f's FileHeader and its batches in their original order, renumbered from 1. Controls are
+// recomputed with Create and every file is validated.
+//
+// The returned files share EntryDetail records with f.
+func (f *File) SplitBySEC() (map[string]*File, error) {
+	out := make(map[string]*File)
+	file := func(sec string) *File {
+		if file, exists := out[sec]; exists {
+			return file
+		}
+		file := NewFile()
+		file.Header = f.Header
+		file.Header.ID = file.ID
+		if f.validateOpts != nil {
+			file.SetValidation(f.validateOpts)
+		}
+		out[sec] = file
+		return file
+	}
+
+	for _, b := range f.Batches {
+		bh := *b.GetHeader() // don't let the BatchHeader escape and mutate
+		file := file(bh.StandardEntryClassCode)
+		bh.ID = base.ID()
+		bh.BatchNumber = len(file.Batches) + len(file.IATBatches) + 1
+
+		batch, err := NewBatch(&bh)
+		if err != nil {
+			return nil, err
+		}
+		if f.validateOpts != nil {
+			batch.SetValidation(f.validateOpts)
+		}
+		for _, entry := range b.GetEntries() {
+			batch.AddEntry(entry)
+		}
+		for _, entry := range b.GetADVEntries() {
+			batch.AddADVEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, err
+		}
+		file.AddBatch(batch)
+	}
+	for i := range f.IATBatches {
+		bh := *f.IATBatches[i].GetHeader()
+		file := file(IAT)
+		bh.ID = base.ID()
+		bh.BatchNumber = len(file.Batches) + len(file.IATBatches) + 1
+
+		batch := NewIATBatch(&bh)
+		if f.validateOpts != nil {
+			batch.SetValidation(f.validateOpts)
+		}
+		for _, entry := range f.IATBatches[i].GetEntries() {
+			batch.AddEntry(entry)
+		}
+		if err := batch.Create(); err != nil {
+			return nil, err
+		}
+		file.AddIATBatch(batch)
+	}
+
+	for sec, file := range out {
+		if err := file.Create(); err != nil {
+			return nil, fmt.Errorf("creating %s file: %w", sec, err)
+		}
+		if err := file.Validate(); err != nil {
+			return nil, fmt.Errorf("validating %s file: %w", sec, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,170 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile__SplitByEntryCount(t *testing.T) {
+	file := mockLargeFilePPD(t, 50)
+
+	files, err := file.SplitByEntryCount(10)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+
+	var total int
+	modifier := file.Header
+	for _, f := range files {
+		require.NoError(t, f.Validate())
+		modifier.NextFileIDModifier()
+		require.NotEqual(t, file.Header.FileIDModifier, f.Header.FileIDModifier)
+		require.Equal(t, modifier.FileIDModifier, f.Header.FileIDModifier)
+		require.Len(t, f.Batches, 1)
+		require.Equal(t, 1, f.Batches[0].GetHeader().BatchNumber)
+		require.Equal(t, 10, f.Control.EntryAddendaCount)
+		total += f.Control.TotalCreditEntryDollarAmountInFile
+
+		var buf bytes.Buffer
+		require.NoError(t, NewWriter(&buf).Write(f))
+	}
+	require.Equal(t, file.Control.TotalCreditEntryDollarAmountInFile, total)
+
+	// Uneven splits continue batches in the next file
+	files, err = file.SplitByEntryCount(15)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	require.Len(t, files[3].Batches[0].GetEntries(), 5)
+
+	_, err = file.SplitByEntryCount(0)
+	require.ErrorIs(t, err, ErrSplitMaxEntries)
+}
+
+func TestFile__SplitByEntryCountBatches(t *testing.T) {
+	file, err := ReadFile("test/testdata/web-debit.ach")
+	require.NoError(t, err)
+
+	files, err := file.SplitByEntryCount(1)
+	require.NoError(t, err)
+
+	var entries int
+	for _, b := range file.Batches {
+		entries += len(b.GetEntries())
+	}
+	require.Len(t, files, entries)
+	for _, f := range files {
+		require.NoError(t, f.Validate())
+		require.Len(t, f.Batches, 1)
+	}
+
+	// All batches fit in one file
+	files, err = file.SplitByEntryCount(entries)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Len(t, files[0].Batches, len(file.Batches))
+	for i, b := range files[0].Batches {
+		require.Equal(t, i+1, b.GetHeader().BatchNumber)
+	}
+
+	// the source file uses A so the 35 modifiers B through 9 remain
+	files, err = mockLargeFilePPD(t, 35).SplitByEntryCount(1)
+	require.NoError(t, err)
+	require.Equal(t, "9", files[len(files)-1].Header.FileIDModifier)
+
+	_, err = mockLargeFilePPD(t, 36).SplitByEntryCount(1)
+	require.ErrorIs(t, err, ErrSplitFileIDModifiers)
+}
+
+func TestFile__SplitByEntryCountFileIDModifier(t *testing.T) {
+	split := func(modifier string) ([]*File, error) {
+		file := mockLargeFilePPD(t, 2)
+		file.Header.FileIDModifier = modifier
+		return file.SplitByEntryCount(1)
+	}
+
+	// blank and unknown modifiers start from A
+	for _, modifier := range []string{"", " ", "*"} {
+		files, err := split(modifier)
+		require.NoError(t, err)
+		require.Equal(t, "A", files[0].Header.FileIDModifier)
+		require.Equal(t, "B", files[1].Header.FileIDModifier)
+	}
+
+	files, err := split("c")
+	require.NoError(t, err)
+	require.Equal(t, "D", files[0].Header.FileIDModifier)
+
+	files, err = split("Z")
+	require.NoError(t, err)
+	require.Equal(t, "0", files[0].Header.FileIDModifier)
+	require.Equal(t, "1", files[1].Header.FileIDModifier)
+
+	_, err = split("9")
+	require.ErrorIs(t, err, ErrSplitFileIDModifiers)
+}
+
+func TestFile__SplitByEntryCountIAT(t *testing.T) {
+	file, err := ReadFile("test/testdata/iat-debit.ach")
+	require.NoError(t, err)
+
+	files, err := file.SplitByEntryCount(1)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		require.NoError(t, f.Validate())
+		require.Len(t, f.IATBatches, 1)
+	}
+}
+
+func TestFile__SplitBySEC(t *testing.T) {
+	file, err := ReadFile("test/testdata/web-debit.ach")
+	require.NoError(t, err)
+
+	iat, err := ReadFile("test/testdata/iat-debit.ach")
+	require.NoError(t, err)
+	file.AddIATBatch(iat.IATBatches[0])
+	require.NoError(t, file.Create())
+	require.NoError(t, file.Validate())
+
+	files, err := file.SplitBySEC()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	require.Len(t, files[WEB].Batches, 2)
+	require.Len(t, files[PPD].Batches, 1)
+	require.Len(t, files[IAT].IATBatches, 1)
+	for sec, f := range files {
+		require.NoError(t, f.Validate(), sec)
+		require.Equal(t, file.Header.ImmediateOrigin, f.Header.ImmediateOrigin)
+		for i, b := range f.Batches {
+			require.Equal(t, sec, b.GetHeader().StandardEntryClassCode)
+			require.Equal(t, i+1, b.GetHeader().BatchNumber)
+		}
+	}
+	require.Equal(t, 1, files[PPD].Batches[0].GetHeader().BatchNumber)
+	require.Equal(t, 3, file.Batches[2].GetHeader().BatchNumber)
+
+	// ADV files keep their ADV controls
+	files, err = mockFileADV(t).SplitBySEC()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.NoError(t, files[ADV].Validate())
+}
@@ -20,6 +20,7 @@ package ach
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -141,6 +142,39 @@ func BenchmarkFileBatchCount(b *testing.B) {
 	}
 }
 
+// TestFile__TruncatedBatchCount reads a file missing its last batch and expects the counts
+// recorded in the FileControl to no longer match.
+func TestFile__TruncatedBatchCount(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+
+	// Drop the last batch (header through control) but keep the FileControl
+	lines := strings.Split(string(bs), "\n")
+	start, end := -1, -1
+	for i := range lines {
+		if strings.HasPrefix(lines[i], "5") {
+			start = i
+		}
+		if strings.HasPrefix(lines[i], "8") {
+			end = i
+		}
+	}
+	require.True(t, start > 0 && end > start)
+	truncated := strings.Join(append(lines[:start:start], lines[end+1:]...), "\n")
+
+	file, err := NewReader(strings.NewReader(truncated)).Read()
+	require.NoError(t, err)
+
+	err = file.Validate()
+	require.Error(t, err)
+
+	var fieldErr ErrFileCalculatedControlEquality
+	require.ErrorAs(t, err, &fieldErr)
+	require.Equal(t, "BatchCount", fieldErr.Field)
+	require.Equal(t, 2, fieldErr.CalculatedValue)
+	require.Equal(t, 3, fieldErr.ControlValue)
+}
+
 // testFileEntryAddenda validates an addenda entry
 func testFileEntryAddenda(t testing.TB) {
 	file := mockFilePPD(t)
@@ -2241,3 +2275,437 @@ func TestFile_FlattenBatches_PreservesFileIDModifier(t *testing.T) {
 		t.Errorf("FileIDModifier not preserved: want 'B' got %s", flattened.Header.FileIDModifier)
 	}
 }
+
+func TestFile_SetValidation_AllowInvalidCheckDigit(t *testing.T) {
+	f := NewFile()
+	f.SetHeader(mockFileHeader())
+	f.AddBatch(mockBatchPPD(t))
+	require.NoError(t, f.Create())
+	require.NoError(t, f.Validate())
+
+	f.Batches[0].GetEntries()[0].CheckDigit = "1"
+	require.ErrorContains(t, f.Validate(), "does not match calculated check digit")
+
+	f.SetValidation(&ValidateOpts{
+		AllowInvalidCheckDigit: true,
+	})
+	require.NoError(t, f.Validate())
+}
+
+func TestValidateOpts_MergeAllowZeroEntryAmount(t *testing.T) {
+	opts := (&ValidateOpts{}).merge(&ValidateOpts{AllowZeroEntryAmount: true})
+	require.True(t, opts.AllowZeroEntryAmount)
+}
+
+func TestFile_ValidateAll(t *testing.T) {
+	file := mockFilePPD(t)
+	require.Empty(t, file.ValidateAll())
+
+	b2 := mockBatchPPD(t)
+	b2.GetHeader().BatchNumber = 2
+	require.NoError(t, b2.Create())
+	file.AddBatch(b2)
+	require.NoError(t, file.Create())
+	require.Empty(t, file.ValidateAll())
+
+	amount := file.Batches[1].GetEntries()[0].Amount
+	file.Batches[0].GetEntries()[0].IndividualName = "®"
+	file.Batches[1].GetEntries()[0].Amount = -1
+	file.Header.ImmediateOriginName = "®"
+
+	errs := file.ValidateAll()
+	require.Len(t, errs, 3)
+	require.ErrorIs(t, errs[0], ErrNonAlphanumeric)
+
+	var be *BatchError
+	require.ErrorAs(t, errs[1], &be)
+	require.Equal(t, 1, be.BatchNumber)
+	require.ErrorIs(t, errs[1], ErrNonAlphanumeric)
+
+	require.ErrorAs(t, errs[2], &be)
+	require.Equal(t, 2, be.BatchNumber)
+	require.ErrorIs(t, errs[2], ErrNegativeAmount)
+
+	// SEC rules are checked once the records are valid
+	file.Header.ImmediateOriginName = "My Bank Name"
+	file.Batches[0].GetEntries()[0].IndividualName = "Jane Doe"
+	file.Batches[1].GetEntries()[0].Amount = amount
+	file.Batches[1].GetHeader().StandardEntryClassCode = CCD
+
+	errs = file.ValidateAll()
+	require.NotEmpty(t, errs)
+	require.ErrorIs(t, errs[0], ErrBatchSECType)
+
+	file.SetValidation(&ValidateOpts{SkipAll: true})
+	require.Empty(t, file.ValidateAll())
+}
+
+func TestFile_ValidateAllIAT(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	require.Empty(t, file.ValidateAll())
+
+	file.IATBatches[0].GetEntries()[0].Addenda10.Name = ""
+	errs := file.ValidateAll()
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrConstructor)
+	require.ErrorContains(t, errs[0], "Name")
+}
+
+func TestFile_RequireMatchingOrigin(t *testing.T) {
+	file := mockFilePPD(t)
+	file.Header.ImmediateOrigin = "231380104"
+	require.NoError(t, file.Validate())
+
+	file.SetValidation(&ValidateOpts{RequireMatchingOrigin: true})
+	err := file.Validate()
+	require.ErrorIs(t, err, ErrFileODFIOrigin)
+	require.Len(t, file.ValidateAll(), 1)
+
+	file.Header.ImmediateOrigin = " " + file.Batches[0].GetHeader().ODFIIdentification
+	require.NoError(t, file.Validate())
+	require.Empty(t, file.ValidateAll())
+
+	opts := (&ValidateOpts{}).merge(&ValidateOpts{RequireMatchingOrigin: true})
+	require.True(t, opts.RequireMatchingOrigin)
+}
+
+func TestFile_CreateAll(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(mockPPDEntryDetail())
+	file.AddBatch(batch)
+
+	// Create alone leaves the batch control empty
+	require.NoError(t, file.Create())
+	require.Equal(t, 0, file.Control.TotalCreditEntryDollarAmountInFile)
+	require.Error(t, file.Validate())
+
+	require.NoError(t, file.CreateAll())
+	require.Equal(t, batch.GetControl().TotalCreditEntryDollarAmount, file.Control.TotalCreditEntryDollarAmountInFile)
+	require.Equal(t, 1, file.Control.BatchCount)
+	require.Equal(t, 1, file.Control.EntryAddendaCount)
+	require.NoError(t, file.Validate())
+
+	// batch errors are returned from CreateAll
+	batch.GetEntries()[0].TransactionCode = 0
+	require.Error(t, file.CreateAll())
+
+	// zero batches is still an error
+	require.ErrorIs(t, NewFile().SetHeader(mockFileHeader()).CreateAll(), ErrFileNoBatches)
+}
+
+func TestFile_BlockCount(t *testing.T) {
+	file := mockFilePPD(t)
+	require.Equal(t, 1, file.Control.BlockCount)
+
+	// files written without the nines padding still read cleanly
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 10)
+	unpadded := strings.Join(lines[:5], "\n")
+
+	read, err := NewReader(strings.NewReader(unpadded)).Read()
+	require.NoError(t, err)
+	require.Equal(t, 1, read.Control.BlockCount)
+
+	file.Control.BlockCount = 2
+	err = file.Validate()
+	require.ErrorContains(t, err, "BlockCount")
+	require.Len(t, file.ValidateAll(), 1)
+
+	file.SetValidation(&ValidateOpts{AllowInvalidBlockCount: true})
+	require.NoError(t, file.Validate())
+	require.Empty(t, file.ValidateAll())
+}
+
+func TestFile_EachEntry(t *testing.T) {
+	file := mockFilePPD(t)
+	second := NewBatchPPD(mockBatchPPDHeader())
+	second.AddEntry(mockPPDEntryDetail())
+	second.AddEntry(mockPPDEntryDetail())
+	file.AddBatch(second)
+
+	var indexes []int
+	total := 0
+	err := file.EachEntry(func(batchIndex int, ed *EntryDetail) error {
+		indexes = append(indexes, batchIndex)
+		total += ed.Amount
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 1}, indexes)
+	require.Equal(t, 100000000+2*second.GetEntries()[0].Amount, total)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = file.EachEntry(func(_ int, _ *EntryDetail) error {
+		calls++
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, 1, calls)
+
+	entries := file.AllEntries()
+	require.Len(t, entries, 3)
+	require.Same(t, second.GetEntries()[1], entries[2])
+
+	require.Empty(t, NewFile().AllEntries())
+
+	// nil batches are skipped
+	file.Batches = append(file.Batches, nil)
+	require.Len(t, file.AllEntries(), 3)
+}
+
+func TestFile_FindEntryByTrace(t *testing.T) {
+	file := mockFilePPD(t)
+	second := NewBatchPPD(mockBatchPPDHeader())
+	second.AddEntry(mockPPDEntryDetail())
+	second.GetEntries()[0].SetTraceNumber("12104288", 7)
+	file.AddBatch(second)
+
+	entry, batch, found := file.FindEntryByTrace(second.GetEntries()[0].TraceNumber)
+	require.True(t, found)
+	require.Same(t, second.GetEntries()[0], entry)
+	require.Equal(t, second, batch)
+
+	entry, batch, found = file.FindEntryByTrace(file.Batches[0].GetEntries()[0].TraceNumber)
+	require.True(t, found)
+	require.Same(t, file.Batches[0].GetEntries()[0], entry)
+	require.Equal(t, file.Batches[0], batch)
+
+	second.GetEntries()[0].TraceNumber = "42"
+	entry, _, found = file.FindEntryByTrace("000000000000042")
+	require.True(t, found)
+	require.Same(t, second.GetEntries()[0], entry)
+
+	_, _, found = file.FindEntryByTrace("999999990000001")
+	require.False(t, found)
+
+	// duplicate trace numbers return the first entry, FindAllEntriesByTrace returns each
+	second.AddEntry(mockPPDEntryDetail())
+	dup := second.GetEntries()[1].TraceNumber
+	entry, batch, found = file.FindEntryByTrace(dup)
+	require.True(t, found)
+	require.Same(t, file.Batches[0].GetEntries()[0], entry)
+	require.Equal(t, file.Batches[0], batch)
+	require.Len(t, file.FindAllEntriesByTrace(dup), 2)
+	require.Empty(t, file.FindAllEntriesByTrace("1"))
+}
+
+func TestFile_BatchSummary(t *testing.T) {
+	file := mockFilePPD(t)
+	cor := mockBatchCOR(t)
+	file.AddBatch(cor)
+	file.AddIATBatch(mockIATBatch(t))
+
+	ret, err := mockPPDEntryDetail().BuildReturn("R01")
+	require.NoError(t, err)
+	returns := NewBatchPPD(mockBatchPPDHeader())
+	returns.AddEntry(ret)
+	file.AddBatch(returns)
+
+	require.Equal(t, map[string]int{PPD: 2, COR: 1, IAT: 1}, file.BatchSummary())
+
+	forward, returned, noc := file.CategoryCounts()
+	require.Equal(t, 2, forward)
+	require.Equal(t, 1, returned)
+	require.Equal(t, 1, noc)
+
+	require.Empty(t, NewFile().BatchSummary())
+	forward, returned, noc = NewFile().CategoryCounts()
+	require.Zero(t, forward+returned+noc)
+}
+
+func TestFile__WriteJSON(t *testing.T) {
+	for _, name := range []string{"ppd-debit.ach", "web-debit.ach", "iat-debit.ach", "return-WEB.ach", "cor-example.ach"} {
+		t.Run(name, func(t *testing.T) {
+			file, err := ReadFile(filepath.Join("test", "testdata", name))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, file.WriteJSON(&buf))
+			require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+			again, err := FileFromJSON(buf.Bytes())
+			require.NoError(t, err)
+			require.Empty(t, DiffFiles(file, again))
+		})
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	for _, name := range []string{"ppd-debit.ach", "web-debit.ach", "iat-debit.ach", "return-WEB.ach", "cor-example.ach"} {
+		t.Run(name, func(t *testing.T) {
+			original, err := os.ReadFile(filepath.Join("test", "testdata", name))
+			require.NoError(t, err)
+			file, err := NewReader(bytes.NewReader(original)).Read()
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, file.WriteJSON(&buf))
+			again, err := ReadJSON(&buf)
+			require.NoError(t, err)
+
+			// Nacha -> JSON -> Nacha
+			var first, second bytes.Buffer
+			require.NoError(t, NewWriter(&first).Write(&file))
+			require.NoError(t, NewWriter(&second).Write(again))
+			require.Equal(t, first.String(), second.String())
+		})
+	}
+
+	_, err := ReadJSON(strings.NewReader(""))
+	require.Error(t, err)
+}
+
+func TestFile__TotalCreditsAndDebits(t *testing.T) {
+	for _, name := range []string{"ppd-debit.ach", "web-debit.ach", "iat-debit.ach", "20110805A.ach"} {
+		t.Run(name, func(t *testing.T) {
+			file, err := ReadFile(filepath.Join("test", "testdata", name))
+			require.NoError(t, err)
+			require.Equal(t, file.Control.TotalCreditEntryDollarAmountInFile, file.TotalCredits())
+			require.Equal(t, file.Control.TotalDebitEntryDollarAmountInFile, file.TotalDebits())
+		})
+	}
+
+	file := mockFilePPD(t)
+	credits := file.TotalCredits()
+	require.Positive(t, credits)
+	require.Equal(t, 0, file.TotalDebits())
+	require.InDelta(t, float64(credits)/100, file.TotalCreditsDollars(), 0.001)
+	require.Equal(t, 0.0, file.TotalDebitsDollars())
+
+	// Totals come from the entries so they detect an unbalanced FileControl
+	file.Batches[0].GetEntries()[0].Amount += 100
+	require.Equal(t, credits+100, file.TotalCredits())
+	require.NotEqual(t, file.Control.TotalCreditEntryDollarAmountInFile, file.TotalCredits())
+
+	adv := mockFileADV(t)
+	require.Equal(t, adv.ADVControl.TotalCreditEntryDollarAmountInFile, adv.TotalCredits())
+	require.Equal(t, adv.ADVControl.TotalDebitEntryDollarAmountInFile, adv.TotalDebits())
+
+	// Batchers outside the package are summed through their entries
+	custom := mockFilePPD(t)
+	custom.Batches[0] = customBatcher{custom.Batches[0]}
+	require.Equal(t, custom.Control.TotalCreditEntryDollarAmountInFile, custom.TotalCredits())
+}
+
+// customBatcher is a Batcher which only exposes the Batcher interface
+type customBatcher struct {
+	Batcher
+}
+
+func TestFile__RequireBatches(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.SetValidation(&ValidateOpts{AllowZeroBatches: true})
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	empty, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.NoError(t, empty.Validate())
+
+	err = empty.ValidateWith(&ValidateOpts{RequireBatches: true})
+	require.ErrorIs(t, err, ErrFileNoBatches)
+
+	require.NoError(t, mockFilePPD(t).ValidateWith(&ValidateOpts{RequireBatches: true}))
+
+	require.Empty(t, empty.ValidateAll())
+	empty.SetValidation(&ValidateOpts{RequireBatches: true})
+	errs := empty.ValidateAll()
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrFileNoBatches)
+}
+
+func TestFile__EntryHashTruncation(t *testing.T) {
+	// 1000 entries to 23138010 sum past 10 digits
+	file := mockLargeFilePPD(t, 1000)
+	require.Greater(t, 1000*23138010, 10_000_000_000)
+	require.Equal(t, (1000*23138010)%10_000_000_000, file.Control.EntryHash)
+	require.Equal(t, 3138010000, file.Control.EntryHash)
+	require.NoError(t, file.Validate())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.NoError(t, read.Validate())
+	require.Equal(t, "3138010000", read.Control.EntryHashField())
+
+	// Entry hashes are recalculated from entries rather than trusted from batch controls
+	iat, err := ReadFile(filepath.Join("test", "testdata", "iat-debit.ach"))
+	require.NoError(t, err)
+	require.NoError(t, iat.Validate())
+	iat.IATBatches[0].GetControl().EntryHash++
+	iat.Control.EntryHash++
+	var fe ErrFileCalculatedControlEquality
+	require.ErrorAs(t, iat.Validate(), &fe)
+	require.Equal(t, "EntryHash", fe.Field)
+}
+
+func TestFile__SortBatches(t *testing.T) {
+	file, err := ReadFile(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+	require.Len(t, file.Batches, 3)
+
+	first, second, third := file.Batches[0], file.Batches[1], file.Batches[2]
+	first.GetHeader().EffectiveEntryDate = "190817"
+	second.GetHeader().EffectiveEntryDate = "190816"
+	third.GetHeader().EffectiveEntryDate = "190816"
+
+	file.SortBatches()
+	require.Same(t, third, file.Batches[0]) // PPD sorts before WEB on the same date
+	require.Same(t, second, file.Batches[1])
+	require.Same(t, first, file.Batches[2])
+	for i, b := range file.Batches {
+		require.Equal(t, i+1, b.GetHeader().BatchNumber)
+		require.Equal(t, i+1, b.GetControl().BatchNumber)
+	}
+	require.NoError(t, file.Create())
+	require.NoError(t, file.Validate())
+
+	// Sorting again is stable
+	file.SortBatches()
+	require.Same(t, third, file.Batches[0])
+	require.Same(t, second, file.Batches[1])
+
+	// nil batches are moved to the end without being numbered
+	file.Batches = []Batcher{nil, first, nil, second}
+	file.SortBatches()
+	require.Same(t, second, file.Batches[0])
+	require.Same(t, first, file.Batches[1])
+	require.Nil(t, file.Batches[2])
+	require.Nil(t, file.Batches[3])
+	require.Equal(t, 2, first.GetHeader().BatchNumber)
+}
+
+func TestFile__ByteSize(t *testing.T) {
+	for _, name := range []string{"ppd-debit.ach", "web-debit.ach", "iat-debit.ach", "flattenADVBatchesOneBatchHeader.ach"} {
+		t.Run(name, func(t *testing.T) {
+			file, err := ReadFile(filepath.Join("test", "testdata", name))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			w.BypassValidation = true
+			require.NoError(t, w.Write(file))
+			require.Equal(t, buf.Len(), file.ByteSize())
+			require.Equal(t, buf.Len()/(RecordLength+1)/10, file.BlockCount())
+
+			opts := &WriteOpts{LineEnding: "\r\n"}
+			buf.Reset()
+			w = NewWriterWithOpts(&buf, opts)
+			w.BypassValidation = true
+			require.NoError(t, w.Write(file))
+			require.Equal(t, buf.Len(), file.ByteSizeWithOpts(opts))
+		})
+	}
+
+	file := mockLargeFilePPD(t, 25)
+	require.Equal(t, 3, file.BlockCount()) // 4 + 25 records
+	require.Equal(t, 30*95, file.ByteSize())
+}
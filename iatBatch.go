@@ -631,6 +631,12 @@ func (iatBatch *IATBatch) Validate() error {
 		if len(entry.Addenda18) > 5 {
 			return iatBatch.Error("Addenda18", NewErrBatchAddendaCount(len(entry.Addenda18), 5))
 		}
+		if iatBatch.validateOpts != nil && iatBatch.validateOpts.RequireIATAddendaRecords {
+			if count := entry.addendaCount(); count != entry.AddendaRecords {
+				return iatBatch.Error("AddendaCount", fieldError("AddendaRecords",
+					NewErrBatchExpectedAddendaCount(count, entry.AddendaRecords), entry.AddendaRecordsField()))
+			}
+		}
 		if iatBatch.Header.ServiceClassCode == AutomatedAccountingAdvices {
 			return iatBatch.Error("ServiceClassCode", ErrBatchServiceClassCode, iatBatch.Header.ServiceClassCode)
 		}
@@ -659,9 +665,18 @@ func (iatBatch *IATBatch) Validate() error {
 
 // SetValidation stores ValidateOpts on the Batch which are to be used to override
 // the default NACHA validation rules.
+//
+// Non-nil opts are also stored on each IATEntryDetail currently in the IATBatch.
 func (iatBatch *IATBatch) SetValidation(opts *ValidateOpts) {
 	if iatBatch == nil {
 		return
 	}
 	iatBatch.validateOpts = opts
+
+	if opts == nil {
+		return
+	}
+	for i := range iatBatch.Entries {
+		iatBatch.Entries[i].SetValidation(opts)
+	}
 }
@@ -26,8 +26,6 @@ import (
 	"github.com/moov-io/iso4217"
 )
 
-// msgServiceClass
-
 // IATBatchHeader identifies the originating entity and the type of transactions
 // contained in the batch for SEC Code IAT. This record also contains the effective
 // date, or desired settlement date, for all entries contained in this batch. The
@@ -248,7 +246,7 @@ func (iatBh *IATBatchHeader) Parse(record string) {
 			iatBh.OriginatorIdentification = iatBh.parseStringField(reset())
 		case 53:
 			// 51-53 IAT for both consumer and non consumer international payments
-			iatBh.StandardEntryClassCode = string(reset())
+			iatBh.StandardEntryClassCode = strings.ToUpper(strings.TrimSpace(reset()))
 		case 63:
 			// 54-63 Your description of the transaction. This text will appear on the receivers' bank statement.
 			// For example: "Payroll   "
@@ -307,6 +305,33 @@ func (iatBh *IATBatchHeader) String() string {
 	return buf.String()
 }
 
+// SetCurrencyCodes sets ISOOriginatingCurrencyCode and ISODestinationCurrencyCode after checking
+// each is a valid ISO 4217 code. Numeric codes are stored as their three letter code.
+func (iatBh *IATBatchHeader) SetCurrencyCodes(originating, destination string) error {
+	orig, exists := iso4217.Lookup(originating)
+	if !exists {
+		return fieldError("ISOOriginatingCurrencyCode", ErrValidISO4217, originating)
+	}
+	dest, exists := iso4217.Lookup(destination)
+	if !exists {
+		return fieldError("ISODestinationCurrencyCode", ErrValidISO4217, destination)
+	}
+	iatBh.ISOOriginatingCurrencyCode = orig.Code
+	iatBh.ISODestinationCurrencyCode = dest.Code
+	return nil
+}
+
+// SetForeignExchangeIndicator sets ForeignExchangeIndicator after checking it is FF, FV or VF.
+// Lowercase indicators are stored in uppercase.
+func (iatBh *IATBatchHeader) SetForeignExchangeIndicator(indicator string) error {
+	switch v := strings.ToUpper(indicator); v {
+	case "FV", "VF", "FF":
+		iatBh.ForeignExchangeIndicator = v
+		return nil
+	}
+	return fieldError("ForeignExchangeIndicator", ErrForeignExchangeIndicator, indicator)
+}
+
 // isForeignExchangeIndicator ensures foreign exchange indicators of an
 // IATBatchHeader is valid
 func (iatBh *IATBatchHeader) isForeignExchangeIndicator() error {
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/moov-io/base"
+	"github.com/stretchr/testify/require"
 )
 
 // mockIATBatchHeaderFF creates a IAT BatchHeader that is Fixed-Fixed
@@ -744,3 +745,33 @@ func BenchmarkIATBHODFIIdentification(b *testing.B) {
 		testIATBHODFIIdentification(b)
 	}
 }
+
+func TestIATBatchHeader__SetCurrencyCodes(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	require.NoError(t, bh.SetCurrencyCodes("usd", "978"))
+	require.Equal(t, "USD", bh.ISOOriginatingCurrencyCode)
+	require.Equal(t, "EUR", bh.ISODestinationCurrencyCode)
+	require.NoError(t, bh.Validate())
+
+	err := bh.SetCurrencyCodes("USD", "EURO")
+	require.ErrorIs(t, err, ErrValidISO4217)
+	require.ErrorContains(t, err, "ISODestinationCurrencyCode")
+	require.ErrorContains(t, bh.SetCurrencyCodes("XXY", "EUR"), "ISOOriginatingCurrencyCode")
+	require.Equal(t, "EUR", bh.ISODestinationCurrencyCode)
+}
+
+func TestIATBatchHeader__SetForeignExchangeIndicator(t *testing.T) {
+	bh := mockIATBatchHeaderFF()
+	require.NoError(t, bh.SetForeignExchangeIndicator("fv"))
+	require.Equal(t, "FV", bh.ForeignExchangeIndicator)
+	require.NoError(t, bh.SetForeignExchangeIndicator("VF"))
+	require.Equal(t, "VF", bh.ForeignExchangeIndicator)
+
+	err := bh.SetForeignExchangeIndicator("XX")
+	require.ErrorIs(t, err, ErrForeignExchangeIndicator)
+	require.ErrorContains(t, err, "ForeignExchangeIndicator")
+	require.Equal(t, "VF", bh.ForeignExchangeIndicator)
+
+	require.NoError(t, bh.SetForeignExchangeIndicator("FF"))
+	require.NoError(t, bh.Validate())
+}
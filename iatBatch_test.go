@@ -2095,3 +2095,27 @@ func TestIATBatchInvalidServiceClassCode(t *testing.T) {
 	err = iatBatch.verify()
 	require.NoError(t, err)
 }
+
+func TestIATBatch_RequireIATAddendaRecords(t *testing.T) {
+	iatBatch := mockIATBatch(t)
+	entry := iatBatch.GetEntries()[0]
+	entry.AddendaRecords = 7
+	require.NoError(t, iatBatch.Validate())
+
+	// 7 mandatory addenda and 2 Addenda17 records, but the entry still states 7
+	entry.AddAddenda17(mockAddenda17())
+	entry.AddAddenda17(mockAddenda17B())
+	require.NoError(t, iatBatch.build())
+	require.NoError(t, iatBatch.Validate())
+
+	iatBatch.SetValidation(&ValidateOpts{RequireIATAddendaRecords: true})
+	err := iatBatch.Validate()
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	require.Equal(t, "AddendaRecords", fe.FieldName)
+	require.Equal(t, "0007", fe.Value)
+	require.True(t, base.Match(err, NewErrBatchExpectedAddendaCount(9, 7)))
+
+	entry.AddendaRecords = 9
+	require.NoError(t, iatBatch.Validate())
+}
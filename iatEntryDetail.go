@@ -332,6 +332,39 @@ func (iatEd *IATEntryDetail) RDFIIdentificationField() string {
 	return iatEd.stringField(iatEd.RDFIIdentification, 8)
 }
 
+// addendaCount returns the number of addenda records on the IATEntryDetail
+func (iatEd *IATEntryDetail) addendaCount() int {
+	count := len(iatEd.Addenda17) + len(iatEd.Addenda18)
+	if iatEd.Addenda10 != nil {
+		count++
+	}
+	if iatEd.Addenda11 != nil {
+		count++
+	}
+	if iatEd.Addenda12 != nil {
+		count++
+	}
+	if iatEd.Addenda13 != nil {
+		count++
+	}
+	if iatEd.Addenda14 != nil {
+		count++
+	}
+	if iatEd.Addenda15 != nil {
+		count++
+	}
+	if iatEd.Addenda16 != nil {
+		count++
+	}
+	if iatEd.Addenda98 != nil {
+		count++
+	}
+	if iatEd.Addenda99 != nil {
+		count++
+	}
+	return count
+}
+
 // AddendaRecordsField returns a zero padded AddendaRecords string
 func (iatEd *IATEntryDetail) AddendaRecordsField() string {
 	return iatEd.numericField(iatEd.AddendaRecords, 4)
@@ -0,0 +1,45 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JulianDay returns the 3-digit Julian day of the year (001-366) for t, as used by
+// the SettlementDate of a BatchHeader.
+func JulianDay(t time.Time) string {
+	return fmt.Sprintf("%03d", t.YearDay())
+}
+
+// FromJulianDay returns the date of a 3-digit Julian day (e.g. a BatchHeader SettlementDate) in year.
+// Day 366 is only valid in leap years.
+func FromJulianDay(year int, day string) (time.Time, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(day))
+	if err != nil || n < 1 || n > 366 {
+		return time.Time{}, fieldError("JulianDay", ErrValidJulianDay, day)
+	}
+	t := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n-1)
+	if t.Year() != year {
+		return time.Time{}, fieldError("JulianDay", ErrValidJulianDay, day)
+	}
+	return t, nil
+}
@@ -0,0 +1,51 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJulianDay(t *testing.T) {
+	require.Equal(t, "001", JulianDay(time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)))
+	require.Equal(t, "060", JulianDay(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, "365", JulianDay(time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, "366", JulianDay(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestFromJulianDay(t *testing.T) {
+	when, err := FromJulianDay(2024, "060")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), when)
+
+	when, err = FromJulianDay(2023, "060")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), when)
+
+	when, err = FromJulianDay(2024, "366")
+	require.NoError(t, err)
+	require.Equal(t, "366", JulianDay(when))
+
+	for _, day := range []string{"366", "000", "367", "   ", "abc"} {
+		_, err = FromJulianDay(2023, day)
+		require.ErrorIs(t, err, ErrValidJulianDay, day)
+	}
+}
@@ -0,0 +1,70 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+// ToPrenote creates a zero-dollar prenotification (prenote) of the EntryDetail.
+//
+// The entry is copied with the TransactionCode changed to the matching prenote code
+// (e.g. 22 becomes 23), the Amount set to zero and all addenda records removed.
+// Entries which are already prenotes keep their TransactionCode.
+//
+// An error is returned for codes without a prenote equivalent rather than producing an entry
+// which fails validation. These are LoanDebit (55), which Nacha only allows for reversals and
+// has no prenote code, along with return, NOC, zero dollar remittance and ADV codes.
+func (ed *EntryDetail) ToPrenote() (*EntryDetail, error) {
+	code, err := prenoteTransactionCode(ed.TransactionCode)
+	if err != nil {
+		return nil, fieldError("TransactionCode", err, ed.TransactionCode)
+	}
+
+	out := *ed
+	out.TransactionCode = code
+	out.Amount = 0
+	out.AddendaRecordIndicator = 0
+	out.Category = CategoryForward
+	out.Addenda02 = nil
+	out.Addenda05 = nil
+	out.Addenda98 = nil
+	out.Addenda98Refused = nil
+	out.Addenda99 = nil
+	out.Addenda99Contested = nil
+	out.Addenda99Dishonored = nil
+
+	return &out, nil
+}
+
+// prenoteTransactionCode returns the prenote TransactionCode for entries of the given code.
+func prenoteTransactionCode(code int) (int, error) {
+	switch code {
+	case CheckingCredit, CheckingPrenoteCredit:
+		return CheckingPrenoteCredit, nil
+	case CheckingDebit, CheckingPrenoteDebit:
+		return CheckingPrenoteDebit, nil
+	case SavingsCredit, SavingsPrenoteCredit:
+		return SavingsPrenoteCredit, nil
+	case SavingsDebit, SavingsPrenoteDebit:
+		return SavingsPrenoteDebit, nil
+	case GLCredit, GLPrenoteCredit:
+		return GLPrenoteCredit, nil
+	case GLDebit, GLPrenoteDebit:
+		return GLPrenoteDebit, nil
+	case LoanCredit, LoanPrenoteCredit:
+		return LoanPrenoteCredit, nil
+	}
+	return 0, ErrTransactionCode
+}
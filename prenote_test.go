@@ -0,0 +1,76 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryDetail__ToPrenote(t *testing.T) {
+	ed := mockPPDEntryDetail()
+	ed.AddendaRecordIndicator = 1
+	ed.AddAddenda05(mockAddenda05())
+
+	prenote, err := ed.ToPrenote()
+	require.NoError(t, err)
+	require.Equal(t, CheckingPrenoteCredit, prenote.TransactionCode)
+	require.Zero(t, prenote.Amount)
+	require.Zero(t, prenote.AddendaRecordIndicator)
+	require.Empty(t, prenote.Addenda05)
+
+	// the original entry is unchanged
+	require.Equal(t, CheckingCredit, ed.TransactionCode)
+	require.Len(t, ed.Addenda05, 1)
+
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	batch.AddEntry(prenote)
+	require.NoError(t, batch.Create())
+
+	again, err := prenote.ToPrenote()
+	require.NoError(t, err)
+	require.Equal(t, CheckingPrenoteCredit, again.TransactionCode)
+
+	// loan debits have no prenote code
+	ed.TransactionCode = LoanDebit
+	_, err = ed.ToPrenote()
+	require.ErrorIs(t, err, ErrTransactionCode)
+}
+
+func TestPrenoteTransactionCode(t *testing.T) {
+	cases := map[int]int{
+		CheckingCredit: CheckingPrenoteCredit,
+		CheckingDebit:  CheckingPrenoteDebit,
+		SavingsCredit:  SavingsPrenoteCredit,
+		SavingsDebit:   SavingsPrenoteDebit,
+		GLCredit:       GLPrenoteCredit,
+		GLDebit:        GLPrenoteDebit,
+		LoanCredit:     LoanPrenoteCredit,
+	}
+	for code, expected := range cases {
+		got, err := prenoteTransactionCode(code)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	}
+
+	for _, code := range []int{LoanDebit, CheckingReturnNOCCredit, CheckingZeroDollarRemittanceCredit, 0} {
+		_, err := prenoteTransactionCode(code)
+		require.ErrorIs(t, err, ErrTransactionCode)
+	}
+}
@@ -19,6 +19,7 @@ package ach
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -72,6 +73,31 @@ type Reader struct {
 
 	// skipBatchAccumulation is a flag to skip .AddBatch
 	skipBatchAccumulation bool
+
+	// ctx is checked for cancellation while reading, see ReadContext
+	ctx context.Context
+
+	// readAll is set by ReadAll to stop Read at the FileHeader of the next concatenated file
+	readAll bool
+
+	// pendingLine holds the FileHeader record which starts the next concatenated file
+	pendingLine string
+
+	// onRecord is called with each record before it's parsed, see OnRecord
+	onRecord func(recordType string, raw string)
+
+	// opts holds the ReaderOpts set with SetReaderOpts
+	opts ReaderOpts
+
+	// skipped holds a ParseError for each line dropped by ReaderOpts.SkipUnknownRecords
+	skipped []error
+}
+
+// ReaderOpts configures how a Reader handles records which don't follow the Nacha format.
+type ReaderOpts struct {
+	// SkipUnknownRecords drops lines whose first character isn't a known record type, such as stray
+	// comment lines, instead of failing with ErrUnknownRecordType. See Reader.SkippedRecords.
+	SkipUnknownRecords bool `json:"skipUnknownRecords"`
 }
 
 // error returns a new ParseError based on err
@@ -162,12 +188,51 @@ func NewReader(r io.Reader) *Reader {
 	return out
 }
 
+// OnRecord registers fn to be called with each record before it's parsed. recordType is the
+// first character of the record ("1" for a FileHeader, "6" for an EntryDetail, etc) and raw is
+// the full 94 character record, including any "9" block padding. Parse results are unaffected by fn.
+func (r *Reader) OnRecord(fn func(recordType string, raw string)) {
+	if r == nil {
+		return
+	}
+	r.onRecord = fn
+}
+
+// SetReaderOpts stores ReaderOpts controlling how the Reader handles non-compliant records.
+func (r *Reader) SetReaderOpts(opts *ReaderOpts) {
+	if r == nil || opts == nil {
+		return
+	}
+	r.opts = *opts
+}
+
+// SkippedRecords returns a ParseError, with the line number and ErrUnknownRecordType, for each
+// line dropped because of ReaderOpts.SkipUnknownRecords.
+func (r *Reader) SkippedRecords() []error {
+	return r.skipped
+}
+
 func (r *Reader) SetMaxLines(max int) {
 	r.maxLines = max
 }
 
+// SetBufferSize sets the size of the buffer used when reading from the underlying io.Reader.
+// Larger buffers reduce the number of reads for large files. It must be called before Read.
+func (r *Reader) SetBufferSize(n int) {
+	if r == nil || r.scanner == nil || n <= 0 {
+		return
+	}
+	r.scanner.Buffer(make([]byte, 0, n), max(n, bufio.MaxScanTokenSize))
+}
+
 const lineLength = 94
 
+// contextCheckInterval is how many lines are read or written between checks for context cancellation
+const contextCheckInterval = 1000
+
+// byteOrderMark is the UTF-8 encoded BOM some systems prepend to text files
+const byteOrderMark = "\ufeff"
+
 // Read reads each line in the underlying io.Reader and returns a File and any errors encountered.
 //
 // Read enforces ACH formatting rules and the first character of each line determines which parser is used.
@@ -177,22 +242,43 @@ const lineLength = 94
 //
 // Invalid files may be rejected by other financial institutions or ACH tools.
 func (r *Reader) Read() (File, error) {
-	r.lineNum = 0
 	// read through the entire file
 	if r.scanner == nil {
 		return r.File, errors.New("nil scanner")
 	}
-	// r.scanner.Split(scanLines)
-	r.scanner.Split(bufio.ScanRunes)
+	if r.ctx != nil {
+		if err := r.ctx.Err(); err != nil {
+			return r.File, err
+		}
+	}
+	if r.pendingLine != "" {
+		// Continue with the FileHeader of the next file, ReadAll already counted its line
+		if err := r.readLine(r.pendingLine); err != nil {
+			r.errors.Add(err)
+		}
+		r.pendingLine = ""
+	} else {
+		r.lineNum = 0
+		// r.scanner.Split(scanLines)
+		r.scanner.Split(bufio.ScanRunes)
+	}
 
 	// Accumulate the current line
 	var currentLineRuneCount int
 	currentLine := getBuffer()
 	defer saveBuffer(currentLine)
 
+scan:
 	for r.scanner.Scan() {
 		char := r.scanner.Text()
 		switch char {
+		case byteOrderMark:
+			// Drop a UTF-8 byte order mark found before the first record
+			if r.lineNum == 0 && currentLineRuneCount == 0 {
+				continue
+			}
+			currentLineRuneCount += 1
+			currentLine.WriteString(char)
 		case "\n", "\r":
 			// Skip accumulating the newline, but parse the line
 			if currentLineRuneCount > 0 {
@@ -211,12 +297,23 @@ func (r *Reader) Read() (File, error) {
 	fullLine:
 		r.lineNum++
 		if r.lineNum > r.maxLines {
-			r.errors.Add(ErrFileTooLong)
+			r.errors.Add(r.parseError(ErrFileTooLong))
 			return r.File, r.errors
 		}
+		if r.ctx != nil && r.lineNum%contextCheckInterval == 0 {
+			if err := r.ctx.Err(); err != nil {
+				return r.File, err
+			}
+		}
 
 		// skip the buffered line if it's blank
 		line := currentLine.String()
+		if r.readAll && r.startsNextFile(line) {
+			r.pendingLine = line
+			currentLine.Reset()
+			currentLineRuneCount = 0
+			break scan
+		}
 		if !blankLine(line) {
 			// hand off the line to be parsed
 			err := r.readLine(line)
@@ -235,6 +332,7 @@ func (r *Reader) Read() (File, error) {
 
 	// Flush anything that's left over after the scanner completes
 	if currentLineRuneCount > 0 {
+		r.lineNum++
 		err := r.readLine(currentLine.String())
 		if err != nil {
 			r.errors.Add(err)
@@ -256,7 +354,7 @@ func (r *Reader) Read() (File, error) {
 		if r.File.validateOpts == nil || !r.File.validateOpts.AllowMissingFileHeader {
 			// There must be at least one File Header
 			r.recordName = "FileHeader"
-			r.errors.Add(ErrFileHeader)
+			r.errors.Add(r.parseError(ErrFileHeader))
 		}
 	}
 
@@ -266,7 +364,7 @@ func (r *Reader) Read() (File, error) {
 			if (FileControl{}) == r.File.Control {
 				// There must be at least one File Control
 				r.recordName = "FileControl"
-				r.errors.Add(ErrFileControl)
+				r.errors.Add(r.parseError(ErrFileControl))
 			}
 		}
 	} else {
@@ -275,7 +373,7 @@ func (r *Reader) Read() (File, error) {
 			if (ADVFileControl{}) == r.File.ADVControl {
 				// There must be at least one File Control
 				r.recordName = "FileControl"
-				r.errors.Add(ErrFileControl)
+				r.errors.Add(r.parseError(ErrFileControl))
 			}
 		}
 	}
@@ -285,6 +383,71 @@ func (r *Reader) Read() (File, error) {
 	return r.File, r.errors
 }
 
+// ReadAll reads each File from an io.Reader which contains several files concatenated together,
+// each with their own FileHeader and FileControl records. Reading continues until EOF.
+//
+// Each File is read like Read. ReadAll stops at the first file with errors and returns it
+// alongside the files read before it.
+func (r *Reader) ReadAll() ([]*File, error) {
+	r.readAll = true
+	defer func() { r.readAll = false }()
+
+	var files []*File
+	for {
+		file, err := r.Read()
+		files = append(files, &file)
+		if err != nil {
+			return files, err
+		}
+		if r.pendingLine == "" {
+			return files, nil
+		}
+
+		// Start the next file with the same ValidateOpts
+		opts := r.File.validateOpts
+		r.File = File{}
+		r.File.SetValidation(opts)
+		r.IATCurrentBatch = IATBatch{}
+		r.currentBatch = nil
+		r.errors = base.ErrorList{}
+	}
+}
+
+// startsNextFile returns true when line is a FileHeader record following the FileControl of the file being read.
+func (r *Reader) startsNextFile(line string) bool {
+	if !strings.HasPrefix(line, fileHeaderPos) {
+		return false
+	}
+	if r.File.IsADV() {
+		return r.File.ADVControl != (ADVFileControl{})
+	}
+	return r.File.Control != (FileControl{})
+}
+
+// ReadContext parses the file like Read but stops and returns ctx.Err() once ctx is cancelled.
+// The context is checked before reading and periodically between records.
+func (r *Reader) ReadContext(ctx context.Context) (File, error) {
+	r.ctx = ctx
+	defer func() { r.ctx = nil }()
+	return r.Read()
+}
+
+// ReadWithErrors parses the entire file like Read and returns every parsed batch
+// alongside each error encountered. Batches which fail validation are still
+// included in the returned File so callers can inspect the successfully parsed
+// records and report the errors separately.
+func (r *Reader) ReadWithErrors() (*File, []error) {
+	file, err := r.Read()
+	if err == nil {
+		return &file, nil
+	}
+	var el base.ErrorList
+	if errors.As(err, &el) {
+		return &file, []error(el)
+	}
+	return &file, []error{err}
+}
+
 func blankLine(line string) bool {
 	for _, r := range line {
 		if !unicode.IsSpace(r) {
@@ -295,6 +458,14 @@ func blankLine(line string) bool {
 }
 
 func (r *Reader) readLine(line string) error {
+	if r.opts.SkipUnknownRecords && !isKnownRecordType(line) {
+		recordType, _ := utf8.DecodeRuneInString(line)
+		if r.onRecord != nil {
+			r.onRecord(string(recordType), line)
+		}
+		r.skipped = append(r.skipped, r.parseError(NewErrUnknownRecordType(string(recordType))))
+		return nil
+	}
 	lineLength := utf8.RuneCountInString(line)
 	switch {
 	case r.lineNum == 1 && lineLength > RecordLength:
@@ -323,27 +494,49 @@ func (r *Reader) readLine(line string) error {
 		// parse the line
 		if err := r.parseLine(); err != nil {
 			r.errors.Add(r.parseError(NewRecordWrongLengthErr(lineLength)))
-			return err
+			return r.parseError(err)
 		}
 
 	default:
 		r.line = line
 		if err := r.parseLine(); err != nil {
-			return err
+			return r.parseError(err)
 		}
 	}
 	return nil
 }
 
+// isKnownRecordType returns true if line starts with one of the Nacha record type codes
+func isKnownRecordType(line string) bool {
+	if line == "" {
+		return false
+	}
+	switch line[:1] {
+	case fileHeaderPos, batchHeaderPos, entryDetailPos, entryAddendaPos, batchControlPos, fileControlPos:
+		return true
+	}
+	return false
+}
+
 func trimSpacesFromLongLine(s string) string {
-	return strings.TrimSuffix(s[:lineLength], " ")
+	// trim on a rune boundary, lines can contain multi-byte characters
+	count := 0
+	for idx := range s {
+		if count == lineLength {
+			s = s[:idx]
+			break
+		}
+		count++
+	}
+	return strings.TrimSuffix(s, " ")
 }
 
 func rightPadShortLine(s string) (string, error) {
-	if n := len(s); n > RecordLength {
+	n := utf8.RuneCountInString(s)
+	if n > RecordLength {
 		return s, NewRecordWrongLengthErr(n)
 	}
-	return s + strings.Repeat(" ", lineLength-len(s)), nil
+	return s + strings.Repeat(" ", lineLength-n), nil
 }
 
 func (r *Reader) processFixedWidthFile(line string) error {
@@ -363,6 +556,12 @@ func (r *Reader) processFixedWidthFile(line string) error {
 }
 
 func (r *Reader) parseLine() error {
+	// each parse function sets the record it's reading, don't report the previous record's name
+	r.recordName = ""
+	if r.onRecord != nil {
+		r.onRecord(r.line[:1], r.line)
+	}
+
 	switch r.line[:1] {
 	case fileHeaderPos:
 		if err := r.parseFileHeader(); err != nil {
@@ -430,14 +629,15 @@ func (r *Reader) parseLine() error {
 			return err
 		}
 	default:
-		return NewErrUnknownRecordType(r.line[:1])
+		recordType, _ := utf8.DecodeRuneInString(r.line)
+		return NewErrUnknownRecordType(string(recordType))
 	}
 	return nil
 }
 
 // parseBH parses determines whether to parse an IATBatchHeader or BatchHeader
 func (r *Reader) parseBH() error {
-	if r.line[50:53] == IAT || strings.TrimSpace(r.line[04:20]) == IATCOR {
+	if strings.EqualFold(r.line[50:53], IAT) || strings.TrimSpace(r.line[04:20]) == IATCOR {
 		if err := r.parseIATBatchHeader(); err != nil {
 			return err
 		}
@@ -527,8 +727,12 @@ func (r *Reader) parseEntryDetail() error {
 		ed := NewEntryDetail()
 		ed.SetValidation(r.File.validateOpts)
 		ed.Parse(r.line)
-		if err := maybeValidate(ed, r.File.validateOpts); err != nil {
-			return r.parseError(err)
+		// Addenda records follow the entry, so the AddendaRecordIndicator is compared
+		// with them when the batch is validated.
+		if r.File.validateOpts == nil || !r.File.validateOpts.SkipAll {
+			if errs := ed.validateFields(); len(errs) > 0 {
+				return r.parseError(errs[0])
+			}
 		}
 		r.currentBatch.AddEntry(ed)
 	} else {
@@ -568,6 +772,7 @@ func (r *Reader) parseAddenda() error {
 			case "05":
 				addenda05 := NewAddenda05()
 				addenda05.Parse(r.line)
+				addenda05.SetValidation(r.File.validateOpts)
 				if err := maybeValidate(addenda05, r.File.validateOpts); err != nil {
 					return r.parseError(err)
 				}
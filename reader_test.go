@@ -19,6 +19,7 @@ package ach
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/moov-io/base"
 	"github.com/stretchr/testify/require"
@@ -1873,7 +1875,7 @@ func TestCategoryAssignment(t *testing.T) {
 	forwardEntry := mockEntryDetail()
 	forwardEntry.DFIAccountNumber = "1"
 	forwardEntry.Category = CategoryForward
-	forwardEntry.DiscretionaryData = "01"
+	forwardEntry.DiscretionaryData = "S"
 	forwardBatch := NewBatchWEB(mockBatchWEBHeader())
 	forwardBatch.AddEntry(forwardEntry)
 	if err := forwardBatch.Create(); err != nil {
@@ -2179,3 +2181,356 @@ func TestReadFile_PreserveSpacesOptEnabled(t *testing.T) {
 		t.Errorf("Expected company id: '%s', Actual: '%s'", expectedCompanyId, batchControlCompanyId)
 	}
 }
+
+func TestReader__MissingFileControlParseError(t *testing.T) {
+	var line = "101 076401251 0764012510807291511A094101achdestname            companyname                    "
+	_, err := NewReader(strings.NewReader(line)).Read()
+	require.True(t, base.Has(err, ErrFileControl))
+
+	el, ok := err.(base.ErrorList)
+	require.True(t, ok)
+
+	var pErr *base.ParseError
+	require.ErrorAs(t, el[len(el)-1], &pErr)
+	require.Equal(t, 1, pErr.Line)
+	require.Equal(t, "FileControl", pErr.Record)
+}
+
+func TestReader__ShortLastLine(t *testing.T) {
+	var fh = "101 076401251 0764012510807291511A094101achdestname            companyname                    "
+	var bh = "5225companyname                         origid    PPDCHECKPAYMT000002080730   1076401250000001"
+	_, err := NewReader(strings.NewReader(fh + "\n" + bh + "\n" + "6220764012590012")).Read()
+	require.Error(t, err)
+
+	el, ok := err.(base.ErrorList)
+	require.True(t, ok)
+
+	var pErr *base.ParseError
+	require.ErrorAs(t, el[0], &pErr)
+	require.Equal(t, 3, pErr.Line)
+	require.Equal(t, "EntryDetail", pErr.Record)
+
+	var lengthErr RecordWrongLengthErr
+	require.ErrorAs(t, el[0], &lengthErr)
+	require.Equal(t, 16, lengthErr.Length)
+}
+
+func TestReader__UnknownRecordType(t *testing.T) {
+	var fh = "101 076401251 0764012510807291511A094101achdestname            companyname                    "
+	line := "é" + strings.Repeat("a", 93)
+	_, err := NewReader(strings.NewReader(fh + "\n" + line)).Read()
+	require.Error(t, err)
+
+	el, ok := err.(base.ErrorList)
+	require.True(t, ok)
+
+	var pErr *base.ParseError
+	require.ErrorAs(t, el[0], &pErr)
+	require.Equal(t, 2, pErr.Line)
+	require.Equal(t, "", pErr.Record)
+
+	var typeErr ErrUnknownRecordType
+	require.ErrorAs(t, el[0], &typeErr)
+	require.Equal(t, "é", typeErr.Type)
+}
+
+func TestReader__MultiByteLineLength(t *testing.T) {
+	out, err := rightPadShortLine(strings.Repeat("é", 50))
+	require.NoError(t, err)
+	require.Equal(t, 94, utf8.RuneCountInString(out))
+
+	_, err = rightPadShortLine(strings.Repeat("é", 95))
+	var lengthErr RecordWrongLengthErr
+	require.ErrorAs(t, err, &lengthErr)
+	require.Equal(t, 95, lengthErr.Length)
+
+	out = trimSpacesFromLongLine(strings.Repeat("é", 100))
+	require.Equal(t, strings.Repeat("é", 94), out)
+}
+
+func TestReader__ReadWithErrors(t *testing.T) {
+	file := mockFilePPD(t)
+	second := NewBatchPPD(mockBatchPPDHeader())
+	second.AddEntry(mockPPDEntryDetail())
+	require.NoError(t, second.Create())
+	file.AddBatch(second)
+	require.NoError(t, file.Create())
+
+	// corrupt the first batch so it fails validation
+	file.Batches[0].GetControl().TotalCreditEntryDollarAmount = 1
+	file.SetValidation(&ValidateOpts{SkipAll: true})
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	out, errs := NewReader(&buf).ReadWithErrors()
+	require.NotNil(t, out)
+	require.Len(t, out.Batches, 2)
+	require.Len(t, errs, 1)
+
+	var pErr *base.ParseError
+	require.ErrorAs(t, errs[0], &pErr)
+	require.Equal(t, "Batches", pErr.Record)
+
+	buf.Reset()
+	require.NoError(t, NewWriter(&buf).Write(mockFilePPD(t)))
+	out, errs = NewReader(&buf).ReadWithErrors()
+	require.Len(t, out.Batches, 1)
+	require.Empty(t, errs)
+}
+
+func TestReader__ByteOrderMark(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	for _, prefix := range []string{"\ufeff", "\ufeff\r\n", "\n\n", "\r\n  \r\n"} {
+		file, err := NewReader(strings.NewReader(prefix + string(bs))).Read()
+		require.NoError(t, err, "prefix %q", prefix)
+		require.Len(t, file.Batches, 1)
+	}
+
+	// a BOM inside a record is still rejected
+	fh := "101 076401251 0764012510807291511A094101achdestname            companyname                    "
+	_, err = NewReader(strings.NewReader(fh + "\n\ufeff" + fh[1:])).Read()
+	require.Error(t, err)
+}
+
+func TestReader__BatcherTypes(t *testing.T) {
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(mockBatchPPD(t))
+	file.AddBatch(mockBatchCCD(t))
+	file.AddBatch(mockBatchWEB(t))
+	for i, b := range file.Batches {
+		b.GetHeader().BatchNumber = i + 1
+		require.NoError(t, b.Create())
+	}
+	require.NoError(t, file.Create())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	read, err := NewReader(&buf).Read()
+	require.NoError(t, err)
+	require.Len(t, read.Batches, 3)
+
+	var secs []string
+	for _, b := range read.Batches {
+		switch b.(type) {
+		case *BatchPPD:
+			secs = append(secs, PPD)
+		case *BatchCCD:
+			secs = append(secs, CCD)
+		case *BatchWEB:
+			secs = append(secs, WEB)
+		default:
+			t.Fatalf("unexpected batch type %T", b)
+		}
+	}
+	require.Equal(t, []string{PPD, CCD, WEB}, secs)
+}
+
+func TestReader__TraceNumbersAscending(t *testing.T) {
+	file := mockFilePPD(t)
+	entry := mockPPDEntryDetail()
+	entry.SetTraceNumber(mockBatchPPDHeader().ODFIIdentification, 2)
+	file.Batches[0].AddEntry(entry)
+	require.NoError(t, file.Batches[0].Create())
+	require.NoError(t, file.Create())
+
+	// swap the trace numbers so they descend
+	entries := file.Batches[0].GetEntries()
+	entries[0].TraceNumber, entries[1].TraceNumber = entries[1].TraceNumber, entries[0].TraceNumber
+	file.SetValidation(&ValidateOpts{SkipAll: true})
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(file))
+
+	_, errs := NewReader(&buf).ReadWithErrors()
+	require.Len(t, errs, 1)
+
+	var ascErr ErrBatchAscending
+	require.ErrorAs(t, errs[0], &ascErr)
+	require.Equal(t, "121042880000001", ascErr.CurrentTrace)
+	require.Equal(t, "121042880000002", ascErr.PreviousTrace)
+}
+
+func TestReader__SetBufferSize(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(mockFilePPD(t)))
+
+	for _, size := range []int{0, 16, 1024 * 1024} {
+		r := NewReader(bytes.NewReader(buf.Bytes()))
+		r.SetBufferSize(size)
+
+		file, err := r.Read()
+		require.NoError(t, err)
+		require.Len(t, file.Batches, 1)
+	}
+}
+
+// cancelReader cancels its context once more than limit bytes have been read
+type cancelReader struct {
+	io.Reader
+	cancel context.CancelFunc
+	limit  int
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if r.limit -= n; r.limit < 0 {
+		r.cancel()
+	}
+	return n, err
+}
+
+func mockLargeFilePPD(t *testing.T, entries int) *File {
+	t.Helper()
+
+	batch := NewBatchPPD(mockBatchPPDHeader())
+	for i := 0; i < entries; i++ {
+		entry := mockPPDEntryDetail()
+		entry.SetTraceNumber(batch.GetHeader().ODFIIdentification, i+1)
+		batch.AddEntry(entry)
+	}
+	require.NoError(t, batch.Create())
+
+	file := NewFile().SetHeader(mockFileHeader())
+	file.AddBatch(batch)
+	require.NoError(t, file.Create())
+	return file
+}
+
+func TestReader__ReadContext(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).Write(mockLargeFilePPD(t, 2*contextCheckInterval)))
+	raw := buf.Bytes()
+
+	file, err := NewReader(bytes.NewReader(raw)).ReadContext(context.Background())
+	require.NoError(t, err)
+	require.Len(t, file.Batches[0].GetEntries(), 2*contextCheckInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewReader(bytes.NewReader(raw)).ReadContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	r := NewReader(&cancelReader{Reader: bytes.NewReader(raw), cancel: cancel, limit: 10 * 1024})
+	_, err = r.ReadContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, contextCheckInterval, r.lineNum)
+}
+
+func TestReader__ReadAll(t *testing.T) {
+	ppd, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+	web, err := os.ReadFile(filepath.Join("test", "testdata", "web-debit.ach"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	buf.Write(ppd)
+	buf.Write(web)
+
+	files, err := NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Len(t, files[0].Batches, 1)
+	require.Equal(t, PPD, files[0].Batches[0].GetHeader().StandardEntryClassCode)
+	require.Len(t, files[1].Batches, 3)
+	require.Equal(t, WEB, files[1].Batches[0].GetHeader().StandardEntryClassCode)
+	for i := range files {
+		require.NoError(t, files[i].Validate())
+	}
+
+	// A single file is read like Read
+	files, err = NewReader(bytes.NewReader(ppd)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// Read still expects one FileHeader
+	_, err = NewReader(bytes.NewReader(buf.Bytes())).Read()
+	require.ErrorContains(t, err, ErrFileHeader.Error())
+}
+
+func TestReader__ReadAllError(t *testing.T) {
+	ppd, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	// Corrupt the second file's BatchHeader
+	second := strings.Replace(string(ppd), "\n5225", "\n5999", 1)
+	files, err := NewReader(strings.NewReader(string(ppd) + second)).ReadAll()
+	require.Error(t, err)
+	require.Len(t, files, 2)
+
+	// Line numbers continue across files
+	var el base.ErrorList
+	require.ErrorAs(t, err, &el)
+	var pErr *base.ParseError
+	require.ErrorAs(t, el[0], &pErr)
+	require.Equal(t, "BatchHeader", pErr.Record)
+	require.Greater(t, pErr.Line, strings.Count(string(ppd), "\n"))
+}
+
+func TestReader__OnRecord(t *testing.T) {
+	path := filepath.Join("test", "testdata", "ppd-debit.ach")
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	expected, err := ReadFile(path)
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	var lines int
+	r := NewReader(fd)
+	r.OnRecord(func(recordType string, raw string) {
+		counts[recordType]++
+		lines++
+		require.Len(t, raw, RecordLength)
+		require.Equal(t, recordType, raw[:1])
+	})
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, expected.Header, file.Header)
+	require.Equal(t, expected.Control, file.Control)
+
+	require.Equal(t, 1, counts["1"])
+	require.Equal(t, 1, counts["5"])
+	require.Equal(t, 1, counts["6"])
+	require.Equal(t, 1, counts["8"])
+	require.Equal(t, 10, lines)
+}
+
+func TestReader__SkipUnknownRecords(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("test", "testdata", "ppd-debit.ach"))
+	require.NoError(t, err)
+
+	lines := strings.Split(string(bs), "\n")
+	contents := strings.Join(append(lines[:2:2], append([]string{"# exported from the legacy system"}, lines[2:]...)...), "\n")
+
+	// By default the comment line aborts parsing
+	_, err = NewReader(strings.NewReader(contents)).Read()
+	require.True(t, base.Has(err, NewErrUnknownRecordType("#")))
+	require.True(t, base.Has(err, NewRecordWrongLengthErr(33)))
+
+	r := NewReader(strings.NewReader(contents))
+	r.SetReaderOpts(nil) // ignored
+	r.SetReaderOpts(&ReaderOpts{SkipUnknownRecords: true})
+
+	var seen []string
+	r.OnRecord(func(recordType string, raw string) {
+		seen = append(seen, recordType)
+	})
+	file, err := r.Read()
+	require.NoError(t, err)
+	require.NoError(t, file.Validate())
+	require.Len(t, file.Batches, 1)
+	require.Contains(t, seen, "#")
+
+	skipped := r.SkippedRecords()
+	require.Len(t, skipped, 1)
+	var pErr *base.ParseError
+	require.ErrorAs(t, skipped[0], &pErr)
+	require.Equal(t, 3, pErr.Line)
+	require.Equal(t, NewErrUnknownRecordType("#"), pErr.Err)
+}
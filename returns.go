@@ -0,0 +1,166 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"strings"
+	"time"
+)
+
+// BuildReturn creates a return of the EntryDetail for the given return code.
+//
+// The original entry is copied, as Nacha requires for Return Entries, with the TransactionCode
+// changed to the matching automated return code (e.g. 27 becomes 26). An Addenda99 is attached
+// with OriginalTrace and OriginalDFI from the original entry and the Category is set to
+// CategoryReturn. Any other addenda records of the original entry are not included.
+func (ed *EntryDetail) BuildReturn(returnCode string) (*EntryDetail, error) {
+	if LookupReturnCode(returnCode) == nil {
+		if ed.validateOpts == nil || !ed.validateOpts.CustomReturnCodes {
+			return nil, fieldError("ReturnCode", ErrAddenda99ReturnCode, returnCode)
+		}
+	}
+	code, err := returnTransactionCode(ed.TransactionCode)
+	if err != nil {
+		return nil, fieldError("TransactionCode", err, ed.TransactionCode)
+	}
+
+	out := *ed
+	out.TransactionCode = code
+	out.AddendaRecordIndicator = 1
+	out.Category = CategoryReturn
+	out.Addenda02 = nil
+	out.Addenda05 = nil
+	out.Addenda98 = nil
+	out.Addenda98Refused = nil
+	out.Addenda99Contested = nil
+	out.Addenda99Dishonored = nil
+
+	addenda99 := NewAddenda99()
+	addenda99.ReturnCode = returnCode
+	addenda99.OriginalTrace = ed.TraceNumber
+	addenda99.OriginalDFI = ed.RDFIIdentificationField()
+	addenda99.TraceNumber = ed.TraceNumber
+	addenda99.SetValidation(ed.validateOpts)
+	out.Addenda99 = addenda99
+
+	return &out, nil
+}
+
+// BuildNOC creates a Notification of Change for the EntryDetail with the given change code
+// and corrected data. The result belongs in a COR batch.
+//
+// The original entry is copied with a zero Amount and the matching automated return/NOC
+// TransactionCode. An Addenda98 is attached with OriginalTrace and OriginalDFI from the
+// original entry and the Category is set to CategoryNOC. Any other addenda records of the
+// original entry are not included.
+func (ed *EntryDetail) BuildNOC(changeCode, correctedData string) (*EntryDetail, error) {
+	code, err := returnTransactionCode(ed.TransactionCode)
+	if err != nil {
+		return nil, fieldError("TransactionCode", err, ed.TransactionCode)
+	}
+
+	addenda98 := NewAddenda98()
+	addenda98.ChangeCode = changeCode
+	addenda98.OriginalTrace = ed.TraceNumber
+	addenda98.OriginalDFI = ed.RDFIIdentificationField()
+	addenda98.CorrectedData = correctedData
+	addenda98.TraceNumber = ed.TraceNumber
+	if err := addenda98.Validate(); err != nil {
+		return nil, err
+	}
+
+	out := *ed
+	out.TransactionCode = code
+	out.Amount = 0
+	out.AddendaRecordIndicator = 1
+	out.Category = CategoryNOC
+	out.Addenda02 = nil
+	out.Addenda05 = nil
+	out.Addenda98 = addenda98
+	out.Addenda98Refused = nil
+	out.Addenda99 = nil
+	out.Addenda99Contested = nil
+	out.Addenda99Dishonored = nil
+
+	return &out, nil
+}
+
+// ReturnInfo holds the details of a return taken from the Addenda99 of an EntryDetail.
+type ReturnInfo struct {
+	// ReturnCode is the Nacha return reason code, e.g. R01
+	ReturnCode string `json:"returnCode"`
+	// Reason is the short reason of ReturnCode, blank for codes which are not Nacha return codes
+	Reason string `json:"reason"`
+	// Description explains ReturnCode, blank for codes which are not Nacha return codes
+	Description string `json:"description"`
+	// OriginalTrace is the TraceNumber of the returned entry
+	OriginalTrace string `json:"originalTrace"`
+	// OriginalDFI is the Receiving DFI Identification of the returned entry
+	OriginalDFI string `json:"originalDFI"`
+	// ReturnDate is parsed from the YYMMDD date (e.g. the date of death for R14 and R15) and is
+	// the zero time.Time when the date is blank or invalid
+	ReturnDate time.Time `json:"returnDate"`
+}
+
+// ReturnInfo returns the return details of an EntryDetail with an Addenda99 record.
+// False is returned when the EntryDetail has no Addenda99.
+func (ed *EntryDetail) ReturnInfo() (*ReturnInfo, bool) {
+	if ed == nil || ed.Addenda99 == nil {
+		return nil, false
+	}
+	addenda99 := ed.Addenda99
+
+	info := &ReturnInfo{
+		ReturnCode:    addenda99.ReturnCode,
+		OriginalTrace: addenda99.OriginalTrace,
+		OriginalDFI:   addenda99.OriginalDFI,
+	}
+	if code := LookupReturnCode(addenda99.ReturnCode); code != nil {
+		info.Reason = code.Reason
+		info.Description = code.Description
+	}
+	if date := strings.TrimSpace(addenda99.OriginalEntryReturnDate()); date != "" {
+		if t, err := time.Parse("060102", date); err == nil {
+			info.ReturnDate = t
+		}
+	}
+	return info, true
+}
+
+// returnTransactionCode returns the automated return/NOC TransactionCode for entries of the given code.
+func returnTransactionCode(code int) (int, error) {
+	switch code {
+	case CheckingCredit, CheckingPrenoteCredit, CheckingZeroDollarRemittanceCredit:
+		return CheckingReturnNOCCredit, nil
+	case CheckingDebit, CheckingPrenoteDebit, CheckingZeroDollarRemittanceDebit:
+		return CheckingReturnNOCDebit, nil
+	case SavingsCredit, SavingsPrenoteCredit, SavingsZeroDollarRemittanceCredit:
+		return SavingsReturnNOCCredit, nil
+	case SavingsDebit, SavingsPrenoteDebit, SavingsZeroDollarRemittanceDebit:
+		return SavingsReturnNOCDebit, nil
+	case GLCredit, GLPrenoteCredit, GLZeroDollarRemittanceCredit:
+		return GLReturnNOCCredit, nil
+	case GLDebit, GLPrenoteDebit, GLZeroDollarRemittanceDebit:
+		return GLReturnNOCDebit, nil
+	case LoanCredit, LoanPrenoteCredit, LoanZeroDollarRemittanceCredit:
+		return LoanReturnNOCCredit, nil
+	case LoanDebit:
+		return LoanReturnNOCDebit, nil
+	}
+	return 0, ErrTransactionCode
+}
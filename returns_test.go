@@ -0,0 +1,168 @@
+// Licensed to The Moov Authors under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. The Moov Authors licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ach
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryDetail__BuildReturn(t *testing.T) {
+	ed := mockPPDEntryDetail()
+	ed.TransactionCode = CheckingDebit
+	ed.AddAddenda05(mockAddenda05())
+	ed.AddendaRecordIndicator = 1
+
+	ret, err := ed.BuildReturn("R01")
+	require.NoError(t, err)
+	require.Equal(t, CheckingReturnNOCDebit, ret.TransactionCode)
+	require.Equal(t, ed.Amount, ret.Amount)
+	require.Equal(t, CategoryReturn, ret.Category)
+	require.Equal(t, 1, ret.AddendaRecordIndicator)
+	require.Empty(t, ret.Addenda05)
+
+	require.NotNil(t, ret.Addenda99)
+	require.Equal(t, "R01", ret.Addenda99.ReturnCode)
+	require.Equal(t, ed.TraceNumber, ret.Addenda99.OriginalTrace)
+	require.Equal(t, ed.RDFIIdentification, ret.Addenda99.OriginalDFI)
+	require.NoError(t, ret.Addenda99.Validate())
+	require.NoError(t, ret.Validate())
+
+	// the original is unchanged
+	require.Equal(t, CheckingDebit, ed.TransactionCode)
+	require.Len(t, ed.Addenda05, 1)
+	require.Nil(t, ed.Addenda99)
+
+	// the return can be batched and validated
+	bh := mockBatchPPDHeader()
+	bh.ServiceClassCode = DebitsOnly
+	batch := NewBatchPPD(bh)
+	batch.AddEntry(ret)
+	require.NoError(t, batch.Create())
+	require.Equal(t, CategoryReturn, batch.Category())
+}
+
+func TestEntryDetail__BuildReturnErrors(t *testing.T) {
+	ed := mockPPDEntryDetail()
+
+	_, err := ed.BuildReturn("R99")
+	require.ErrorIs(t, err, ErrAddenda99ReturnCode)
+
+	ed.SetValidation(&ValidateOpts{CustomReturnCodes: true})
+	ret, err := ed.BuildReturn("R99")
+	require.NoError(t, err)
+	require.NoError(t, ret.Addenda99.Validate())
+
+	ed.TransactionCode = CheckingReturnNOCCredit
+	_, err = ed.BuildReturn("R01")
+	require.ErrorIs(t, err, ErrTransactionCode)
+}
+
+func TestReturnTransactionCode(t *testing.T) {
+	cases := map[int]int{
+		CheckingCredit:                    CheckingReturnNOCCredit,
+		CheckingPrenoteDebit:              CheckingReturnNOCDebit,
+		SavingsZeroDollarRemittanceCredit: SavingsReturnNOCCredit,
+		SavingsDebit:                      SavingsReturnNOCDebit,
+		GLPrenoteCredit:                   GLReturnNOCCredit,
+		GLDebit:                           GLReturnNOCDebit,
+		LoanCredit:                        LoanReturnNOCCredit,
+		LoanDebit:                         LoanReturnNOCDebit,
+	}
+	for code, expected := range cases {
+		got, err := returnTransactionCode(code)
+		require.NoError(t, err)
+		require.Equal(t, expected, got, "code %d", code)
+	}
+	_, err := returnTransactionCode(CheckingReturnNOCDebit)
+	require.ErrorIs(t, err, ErrTransactionCode)
+}
+
+func TestEntryDetail__BuildNOC(t *testing.T) {
+	ed := mockPPDEntryDetail()
+	ed.AddAddenda05(mockAddenda05())
+	ed.AddendaRecordIndicator = 1
+
+	noc, err := ed.BuildNOC("C01", "1918171614")
+	require.NoError(t, err)
+	require.Equal(t, CheckingReturnNOCCredit, noc.TransactionCode)
+	require.Equal(t, 0, noc.Amount)
+	require.Equal(t, CategoryNOC, noc.Category)
+	require.Empty(t, noc.Addenda05)
+
+	require.NotNil(t, noc.Addenda98)
+	require.Equal(t, "C01", noc.Addenda98.ChangeCode)
+	require.Equal(t, "1918171614", noc.Addenda98.CorrectedData)
+	require.Equal(t, ed.TraceNumber, noc.Addenda98.OriginalTrace)
+	require.Equal(t, ed.RDFIIdentification, noc.Addenda98.OriginalDFI)
+
+	// the original is unchanged
+	require.Equal(t, 100000000, ed.Amount)
+	require.Nil(t, ed.Addenda98)
+
+	// the NOC can be batched as COR
+	batch := NewBatchCOR(mockBatchCORHeader())
+	batch.AddEntry(noc)
+	require.NoError(t, batch.Create())
+	require.Equal(t, CategoryNOC, batch.Category())
+}
+
+func TestEntryDetail__BuildNOCErrors(t *testing.T) {
+	ed := mockPPDEntryDetail()
+
+	_, err := ed.BuildNOC("C99", "1918171614")
+	require.ErrorIs(t, err, ErrAddenda98ChangeCode)
+
+	_, err = ed.BuildNOC("C01", "")
+	require.ErrorIs(t, err, ErrAddenda98CorrectedData)
+
+	ed.TransactionCode = CheckingReturnNOCCredit
+	_, err = ed.BuildNOC("C01", "1918171614")
+	require.ErrorIs(t, err, ErrTransactionCode)
+}
+
+func TestEntryDetail__ReturnInfo(t *testing.T) {
+	ed := mockPPDEntryDetail()
+	ed.TransactionCode = CheckingDebit
+	_, ok := ed.ReturnInfo()
+	require.False(t, ok)
+
+	ret, err := ed.BuildReturn("R14")
+	require.NoError(t, err)
+	ret.Addenda99.DateOfDeath = "240315"
+
+	info, ok := ret.ReturnInfo()
+	require.True(t, ok)
+	require.Equal(t, "R14", info.ReturnCode)
+	require.Equal(t, "Representative payee deceased or unable to continue in that capacity", info.Reason)
+	require.NotEmpty(t, info.Description)
+	require.Equal(t, ed.TraceNumber, info.OriginalTrace)
+	require.Equal(t, ed.RDFIIdentification, info.OriginalDFI)
+	require.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), info.ReturnDate)
+
+	// Blank dates and custom codes leave those fields empty
+	ret.Addenda99.DateOfDeath = ""
+	ret.Addenda99.ReturnCode = "R99"
+	info, ok = ret.ReturnInfo()
+	require.True(t, ok)
+	require.Equal(t, "R99", info.ReturnCode)
+	require.Empty(t, info.Reason)
+	require.True(t, info.ReturnDate.IsZero())
+}
@@ -29,26 +29,36 @@ import (
 )
 
 const (
-	skipAll                          = "skipAll"
-	requireABAOrigin                 = "requireABAOrigin"
-	bypassOrigin                     = "bypassOrigin"
-	bypassOriginValidation           = "bypassOriginValidation"
-	bypassDestination                = "bypassDestination"
-	bypassDestinationValidation      = "bypassDestinationValidation"
-	customTraceNumbers               = "customTraceNumbers"
-	allowZeroBatches                 = "allowZeroBatches"
-	allowMissingFileHeader           = "allowMissingFileHeader"
-	allowMissingFileControl          = "allowMissingFileControl"
-	bypassCompanyIdentificationMatch = "bypassCompanyIdentificationMatch"
-	customReturnCodes                = "customReturnCodes"
-	unequalServiceClassCode          = "unequalServiceClassCode"
-	unorderedBatchNumbers            = "unorderedBatchNumbers"
-	allowUnorderedBatchNumbers       = "allowUnorderedBatchNumbers"
-	allowInvalidCheckDigit           = "allowInvalidCheckDigit"
-	unequalAddendaCounts             = "unequalAddendaCounts"
-	preserveSpaces                   = "preserveSpaces"
-	allowInvalidAmounts              = "allowInvalidAmounts"
-	allowZeroEntryAmount             = "allowZeroEntryAmount"
+	skipAll                            = "skipAll"
+	requireABAOrigin                   = "requireABAOrigin"
+	bypassOrigin                       = "bypassOrigin"
+	bypassOriginValidation             = "bypassOriginValidation"
+	bypassDestination                  = "bypassDestination"
+	bypassDestinationValidation        = "bypassDestinationValidation"
+	customTraceNumbers                 = "customTraceNumbers"
+	allowZeroBatches                   = "allowZeroBatches"
+	allowMissingFileHeader             = "allowMissingFileHeader"
+	allowMissingFileControl            = "allowMissingFileControl"
+	bypassCompanyIdentificationMatch   = "bypassCompanyIdentificationMatch"
+	customReturnCodes                  = "customReturnCodes"
+	unequalServiceClassCode            = "unequalServiceClassCode"
+	unorderedBatchNumbers              = "unorderedBatchNumbers"
+	allowUnorderedBatchNumbers         = "allowUnorderedBatchNumbers"
+	allowInvalidCheckDigit             = "allowInvalidCheckDigit"
+	unequalAddendaCounts               = "unequalAddendaCounts"
+	preserveSpaces                     = "preserveSpaces"
+	allowInvalidAmounts                = "allowInvalidAmounts"
+	allowZeroEntryAmount               = "allowZeroEntryAmount"
+	requireMatchingOrigin              = "requireMatchingOrigin"
+	requireCompanyIdentificationPrefix = "requireCompanyIdentificationPrefix"
+	allowInvalidBlockCount             = "allowInvalidBlockCount"
+	sanitizeNames                      = "sanitizeNames"
+	requireWEBPaymentType              = "requireWEBPaymentType"
+	requireCreditsOrDebitsOnly         = "requireCreditsOrDebitsOnly"
+	requireBatches                     = "requireBatches"
+	requireIATAddendaRecords           = "requireIATAddendaRecords"
+	truncatePaymentRelatedInformation  = "truncatePaymentRelatedInformation"
+	minIndividualNameLength            = "minIndividualNameLength"
 )
 
 // readValidateOpts parses ValidateOpts from the URL query parameters and from the request body.
@@ -77,6 +87,16 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 		preserveSpaces,
 		allowInvalidAmounts,
 		allowZeroEntryAmount,
+		requireMatchingOrigin,
+		requireCompanyIdentificationPrefix,
+		allowInvalidBlockCount,
+		sanitizeNames,
+		requireWEBPaymentType,
+		requireCreditsOrDebitsOnly,
+		requireBatches,
+		requireIATAddendaRecords,
+		truncatePaymentRelatedInformation,
+		minIndividualNameLength,
 	}
 
 	var buf bytes.Buffer
@@ -95,6 +115,15 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 			continue
 		}
 
+		if name == minIndividualNameLength {
+			n, err := strconv.Atoi(input)
+			if err != nil || n < 0 {
+				return nil, nil, fmt.Errorf("%s is an invalid length: %q", name, input)
+			}
+			opts.MinIndividualNameLength = n
+			continue
+		}
+
 		yes, err := strconv.ParseBool(input)
 		if err != nil {
 			return nil, nil, fmt.Errorf("%s is an invalid boolean: %v", name, err)
@@ -134,6 +163,24 @@ func readValidateOpts(request *http.Request) (io.Reader, *ach.ValidateOpts, erro
 			opts.AllowInvalidAmounts = yes
 		case allowZeroEntryAmount:
 			opts.AllowZeroEntryAmount = yes
+		case requireMatchingOrigin:
+			opts.RequireMatchingOrigin = yes
+		case requireCompanyIdentificationPrefix:
+			opts.RequireCompanyIdentificationPrefix = yes
+		case allowInvalidBlockCount:
+			opts.AllowInvalidBlockCount = yes
+		case sanitizeNames:
+			opts.SanitizeNames = yes
+		case requireWEBPaymentType:
+			opts.RequireWEBPaymentType = yes
+		case requireCreditsOrDebitsOnly:
+			opts.RequireCreditsOrDebitsOnly = yes
+		case requireBatches:
+			opts.RequireBatches = yes
+		case requireIATAddendaRecords:
+			opts.RequireIATAddendaRecords = yes
+		case truncatePaymentRelatedInformation:
+			opts.TruncatePaymentRelatedInformation = yes
 		}
 	}
 
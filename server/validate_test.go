@@ -42,3 +42,22 @@ func TestReadValidateOpts(t *testing.T) {
 	require.True(t, opts.AllowUnorderedBatchNumbers)
 	require.True(t, opts.AllowInvalidCheckDigit)
 }
+
+func TestReadValidateOpts__MinIndividualNameLength(t *testing.T) {
+	body := strings.NewReader(`{"minIndividualNameLength":2}`)
+	req, err := http.NewRequest("POST", "/files/f1/validate?minIndividualNameLength=5&truncatePaymentRelatedInformation=true", body)
+	require.NoError(t, err)
+
+	_, opts, err := readValidateOpts(req)
+	require.NoError(t, err)
+	require.Equal(t, 5, opts.MinIndividualNameLength) // query params override body
+	require.True(t, opts.TruncatePaymentRelatedInformation)
+
+	for _, input := range []string{"abc", "-1"} {
+		req, err = http.NewRequest("POST", "/files/f1/validate?minIndividualNameLength="+input, strings.NewReader("{}"))
+		require.NoError(t, err)
+
+		_, _, err = readValidateOpts(req)
+		require.ErrorContains(t, err, "minIndividualNameLength is an invalid length")
+	}
+}
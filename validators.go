@@ -18,7 +18,6 @@
 package ach
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"regexp"
@@ -370,6 +369,16 @@ func (v *validator) isPrenote(code int) bool {
 	return false
 }
 
+func (v *validator) isZeroDollarRemittance(code int) bool {
+	switch code {
+	case CheckingZeroDollarRemittanceCredit, CheckingZeroDollarRemittanceDebit,
+		SavingsZeroDollarRemittanceCredit, SavingsZeroDollarRemittanceDebit,
+		GLZeroDollarRemittanceCredit, GLZeroDollarRemittanceDebit, LoanZeroDollarRemittanceCredit:
+		return true
+	}
+	return false
+}
+
 // isTransactionTypeCode verifies Addenda10 TransactionTypeCode is a valid value
 // This code is used as a Secondary SEC code to help identify the source and purpose of the transaction.
 //
@@ -434,6 +443,35 @@ func (v *validator) isAlphanumeric(s string) error {
 	return nil
 }
 
+// isCompanyIdentification checks a CompanyIdentification starts with a NACHA prefix.
+// An IRS Employer Identification Number (1) is followed by nine digits while the DUNS (3)
+// and user assigned (9) prefixes are followed by letters or digits.
+func (v *validator) isCompanyIdentification(s string) error {
+	s = strings.TrimSpace(s)
+	if len(s) != 10 {
+		return ErrCompanyIdentificationPrefix
+	}
+	switch s[0] {
+	case '1', '3', '9':
+	default:
+		return ErrCompanyIdentificationPrefix
+	}
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			continue
+		case (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'):
+			if s[0] == '1' {
+				// an EIN is only digits
+				return ErrCompanyIdentificationPrefix
+			}
+			continue
+		}
+		return fmt.Errorf("%w: %c", ErrNonAlphanumeric, r)
+	}
+	return nil
+}
+
 // CalculateCheckDigit returns a check digit for a routing number
 // Multiply each digit in the Routing number by a weighting factor. The weighting factors for each digit are:
 // Position: 1 2 3 4 5 6 7 8
@@ -482,17 +520,17 @@ func (v *validator) CalculateCheckDigit(routingNumber string) int {
 // NACHA rules. See CalculateCheckDigit for details on computing the check digit.
 func CheckRoutingNumber(routingNumber string) error {
 	if routingNumber == "" {
-		return errors.New("no routing number provided")
+		return ErrRoutingNumberRequired
 	}
 	if n := utf8.RuneCountInString(routingNumber); n != 9 {
-		return fmt.Errorf("invalid routing number length of %d", n)
+		return fmt.Errorf("%w of %d", ErrRoutingNumberLength, n)
 	}
 
 	check := CalculateCheckDigit(routingNumber)
 	last := int(routingNumber[len(routingNumber)-1]) - 48 // ASCII 0 is 48 decimal
 
 	if check != last {
-		return fmt.Errorf("routing number checksum mismatch: expected %d but got %d", check, last)
+		return fmt.Errorf("%w: expected %d but got %d", ErrRoutingNumberChecksum, check, last)
 	}
 	return nil
 }
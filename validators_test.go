@@ -223,3 +223,25 @@ func TestValidators__validateJulianDay(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckRoutingNumber__Errors(t *testing.T) {
+	require.ErrorIs(t, CheckRoutingNumber(""), ErrRoutingNumberRequired)
+	require.ErrorIs(t, CheckRoutingNumber("1234"), ErrRoutingNumberLength)
+	require.EqualError(t, CheckRoutingNumber("1234"), "invalid routing number length of 4")
+	require.ErrorIs(t, CheckRoutingNumber("231380105"), ErrRoutingNumberChecksum)
+	require.EqualError(t, CheckRoutingNumber("231380105"), "routing number checksum mismatch: expected 4 but got 5")
+
+	// FieldError unwraps to the sentinel errors
+	fh := mockFileHeader()
+	fh.ImmediateDestination = "231380105"
+	err := fh.Validate()
+	require.ErrorIs(t, err, ErrRoutingNumberChecksum)
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	require.Equal(t, "ImmediateDestination", fieldErr.FieldName)
+
+	ed := mockEntryDetail()
+	ed.DFIAccountNumber = ""
+	require.ErrorIs(t, ed.Validate(), ErrConstructor)
+}
@@ -19,6 +19,7 @@ package ach
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -33,6 +34,9 @@ type Writer struct {
 	LineEnding string // configurable line ending to support different consumer requirements
 	// BypassValidation can be set to skip file validation and will allow non-compliant Nacha files to be written.
 	BypassValidation bool
+
+	// ctx is checked for cancellation while writing, see WriteContext
+	ctx context.Context
 }
 
 // WriteOpts defines options for writing a file.
@@ -111,6 +115,18 @@ func (w *Writer) Write(file *File) error {
 	return w.w.Flush()
 }
 
+// WriteContext writes file like Write but stops and returns ctx.Err() once ctx is cancelled.
+// The context is checked before writing and periodically between records. Lines written
+// before cancellation may have already been flushed to the underlying io.Writer.
+func (w *Writer) WriteContext(ctx context.Context, file *File) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.ctx = ctx
+	defer func() { w.ctx = nil }()
+	return w.Write(file)
+}
+
 // Flush writes any buffered data to the underlying io.Writer.
 func (w *Writer) Flush() error {
 	if w == nil || w.w == nil {
@@ -259,6 +275,11 @@ func (w *Writer) writeLine(entry writeEntry) error {
 	}
 
 	w.lineNum++
+	if w.ctx != nil && w.lineNum%contextCheckInterval == 0 {
+		if err := w.ctx.Err(); err != nil {
+			return err
+		}
+	}
 
 	// Avoid allocations by flushing the buffer
 	if w.w.Available() < 94 {
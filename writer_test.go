@@ -19,6 +19,7 @@ package ach
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
@@ -768,3 +769,34 @@ func TestWriteBypassValidation(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// cancelWriter cancels its context once the first write completes
+type cancelWriter struct {
+	io.Writer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	defer w.cancel()
+	return w.Writer.Write(p)
+}
+
+func TestWriter__WriteContext(t *testing.T) {
+	file := mockLargeFilePPD(t, 2*contextCheckInterval)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewWriter(&buf).WriteContext(context.Background(), file))
+	require.Equal(t, 0, strings.Count(buf.String(), "\n")%10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	require.ErrorIs(t, NewWriter(&buf).WriteContext(ctx, file), context.Canceled)
+	require.Zero(t, buf.Len())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	buf.Reset()
+	w := NewWriter(&cancelWriter{Writer: &buf, cancel: cancel})
+	require.ErrorIs(t, w.WriteContext(ctx, file), context.Canceled)
+	require.Less(t, strings.Count(buf.String(), "\n"), contextCheckInterval)
+}